@@ -0,0 +1,130 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrKeyNotLoaded error of the Return of a key missing in the result of a BatchFunction
+	ErrKeyNotLoaded = errors.New("key not loaded")
+)
+
+// BatchFunction load the values of keys, the map contains the Result of each key. An error fail every key
+type BatchFunction[K comparable, V any] func(ctx context.Context, keys []K) (map[K]Result[V], error)
+
+// BatcherConfig dispatch conditions of a Batcher
+type BatcherConfig struct {
+	// Window time a batch collect keys after its first key
+	Window time.Duration
+	// MaxBatchSize number of keys that dispatch a batch before the end of its window, zero is unbounded
+	MaxBatchSize int
+}
+
+// batch keys collected in a window, returns is set before done is closed
+type batch[K comparable, V any] struct {
+	keys       []K
+	indexes    map[K]struct{}
+	returns    map[K]Return
+	done       chan struct{}
+	timer      *time.Timer
+	dispatched bool
+}
+
+// Batcher collect the keys of concurrent Load calls and load them with a single BatchFunction call
+type Batcher[K comparable, V any] struct {
+	mutex    sync.Mutex
+	function BatchFunction[K, V]
+	config   BatcherConfig
+	pending  *batch[K, V]
+}
+
+// NewBatcher create a Batcher that load keys with function
+func NewBatcher[K comparable, V any](function BatchFunction[K, V], config BatcherConfig) *Batcher[K, V] {
+	return &Batcher[K, V]{function: function, config: config}
+}
+
+// add add key to the pending batch, return the batch and whether it must be dispatched now
+func (_self *Batcher[K, V]) add(key K) (*batch[K, V], bool) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	pending := _self.pending
+	if pending == nil {
+		pending = &batch[K, V]{indexes: map[K]struct{}{}, done: make(chan struct{})}
+		pending.timer = time.AfterFunc(_self.config.Window, func() {
+			_self.dispatch(pending)
+		})
+		_self.pending = pending
+	}
+	if _, ok := pending.indexes[key]; !ok {
+		pending.indexes[key] = struct{}{}
+		pending.keys = append(pending.keys, key)
+	}
+	full := _self.config.MaxBatchSize > 0 && len(pending.keys) >= _self.config.MaxBatchSize
+	if full {
+		// a full batch does not take more keys, its timer may still fire and find it dispatched
+		pending.timer.Stop()
+		_self.pending = nil
+	}
+	return pending, full
+}
+
+// dispatch call the batch function once per batch and send the Return of each key to its callers
+func (_self *Batcher[K, V]) dispatch(pending *batch[K, V]) {
+	_self.mutex.Lock()
+	if pending.dispatched {
+		_self.mutex.Unlock()
+		return
+	}
+	pending.dispatched = true
+	if _self.pending == pending {
+		_self.pending = nil
+	}
+	_self.mutex.Unlock()
+	var results map[K]Result[V]
+	ret := runFunction(context.Background(), -1, func(ctx context.Context) Return {
+		var err error
+		results, err = _self.function(ctx, pending.keys)
+		return NewReturn(err)
+	})
+	pending.returns = make(map[K]Return, len(pending.keys))
+	for _, key := range pending.keys {
+		var value V
+		err := ret.Error()
+		if err == nil {
+			if result, ok := results[key]; ok && result != nil {
+				value, err = result.Value(), result.Err()
+			} else {
+				err = ErrKeyNotLoaded
+			}
+		}
+		pending.returns[key] = &typedReturn[V]{result[V]{value: value, err: err}}
+	}
+	close(pending.done)
+}
+
+// Load return the Return of key once its batch is loaded, use ResultOf to get the typed value. A panic of
+// the batch function is returned as a *PanicError to every key of the batch
+func (_self *Batcher[K, V]) Load(key K) Return {
+	return _self.LoadContext(context.Background(), key)
+}
+
+// LoadContext Load that return when the context is done, the batch is still loaded for the other callers
+func (_self *Batcher[K, V]) LoadContext(ctx context.Context, key K) Return {
+	if ctx.Err() != nil {
+		return doneReturns(1, context.Cause(ctx))[0]
+	}
+	pending, full := _self.add(key)
+	if full {
+		go _self.dispatch(pending)
+	}
+	select {
+	case <-pending.done:
+		return pending.returns[key]
+	case <-ctx.Done():
+		cause := context.Cause(ctx)
+		return newStatusReturnWithCause(statusOfCause(cause), cause)
+	}
+}
@@ -0,0 +1,125 @@
+package gauss
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingBatchFunction return a batch function that record the keys of each call, odd keys fail and
+// keys greater than 100 are not loaded
+func recordingBatchFunction(calls *[][]int, mutex *sync.Mutex) BatchFunction[int, string] {
+	return func(ctx context.Context, keys []int) (map[int]Result[string], error) {
+		mutex.Lock()
+		sorted := append([]int{}, keys...)
+		sort.Ints(sorted)
+		*calls = append(*calls, sorted)
+		mutex.Unlock()
+		results := map[int]Result[string]{}
+		for _, key := range keys {
+			switch {
+			case key > 100:
+			case key%2 == 1:
+				results[key] = NewResult("", errNormal)
+			default:
+				results[key] = NewResult(successValue, nil)
+			}
+		}
+		return results, nil
+	}
+}
+
+func Test_GivenLoadsInWindow_WhenJoinCompleteAll_ThenCallBatchFunctionOnce(t *testing.T) {
+	var calls [][]int
+	var mutex sync.Mutex
+	batcher := NewBatcher(recordingBatchFunction(&calls, &mutex), BatcherConfig{Window: 10 * time.Millisecond})
+	funcs := make([]Function, 5)
+	for index := range funcs {
+		key := index
+		funcs[index] = func() Return {
+			return batcher.Load(key % 4)
+		}
+	}
+	returns, _ := JoinCompleteAll(funcs...)
+	assert.Equal(t, [][]int{{0, 1, 2, 3}}, calls)
+	assert.Equal(t, successValue, ResultOf[string](returns[0]).Value())
+	assert.ErrorIs(t, returns[1].Error(), errNormal)
+	assert.Equal(t, successValue, returns[4].ReturnValues()[0])
+	assert.ErrorIs(t, batcher.Load(101).Error(), ErrKeyNotLoaded)
+	assert.Len(t, calls, 2, "a new batch must start after dispatch")
+}
+
+func Test_GivenMaxBatchSize_WhenBatchIsFull_ThenDispatchBeforeWindow(t *testing.T) {
+	var calls [][]int
+	var mutex sync.Mutex
+	batcher := NewBatcher(recordingBatchFunction(&calls, &mutex), BatcherConfig{Window: time.Second, MaxBatchSize: 2})
+	start := time.Now()
+	returns, _ := JoinCompleteAll(func() Return {
+		return batcher.Load(0)
+	}, func() Return {
+		return batcher.Load(2)
+	})
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Nil(t, ErrorOf(returns))
+	assert.Equal(t, [][]int{{0, 2}}, calls)
+}
+
+func Test_GivenFailingBatchFunction_WhenLoad_ThenEveryKeyFail(t *testing.T) {
+	batcher := NewBatcher(func(ctx context.Context, keys []string) (map[string]Result[int], error) {
+		return nil, errNormal
+	}, BatcherConfig{})
+	assert.ErrorIs(t, batcher.Load("a").Error(), errNormal)
+	batcher = NewBatcher(func(ctx context.Context, keys []string) (map[string]Result[int], error) {
+		panic("panic")
+	}, BatcherConfig{})
+	assert.Equal(t, StatusPanicked, StatusOf(batcher.Load("a")))
+}
+
+func Test_GivenContextDone_WhenLoadContext_ThenReturnWithoutCancelBatch(t *testing.T) {
+	var calls [][]int
+	var mutex sync.Mutex
+	batcher := NewBatcher(recordingBatchFunction(&calls, &mutex), BatcherConfig{Window: 30 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	remaining := make(chan Return, 1)
+	go func() {
+		remaining <- batcher.Load(2)
+	}()
+	assert.Equal(t, StatusTimedOut, StatusOf(batcher.LoadContext(ctx, 0)))
+	assert.Nil(t, (<-remaining).Error())
+	assert.Equal(t, StatusTimedOut, StatusOf(batcher.LoadContext(ctx, 0)))
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, [][]int{{0, 2}}, calls)
+}
+
+func Test_GivenDispatchedBatch_WhenDispatchAgain_ThenCallBatchFunctionOnce(t *testing.T) {
+	var calls [][]int
+	var mutex sync.Mutex
+	batcher := NewBatcher(recordingBatchFunction(&calls, &mutex), BatcherConfig{Window: time.Millisecond})
+	pending, _ := batcher.add(4)
+	batcher.dispatch(pending)
+	batcher.dispatch(pending)
+	time.Sleep(10 * time.Millisecond)
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, [][]int{{4}}, calls)
+}
+
+func Test_GivenPanicBatchFunction_WhenJoinCompleteAll_ThenPanicErrorHasSlotIndex(t *testing.T) {
+	batcher := NewBatcher(func(ctx context.Context, keys []string) (map[string]Result[int], error) {
+		panic("panic")
+	}, BatcherConfig{Window: 10 * time.Millisecond})
+	returns, _ := JoinCompleteAll(successFunction, func() Return {
+		return batcher.Load("a")
+	})
+	var panicError *PanicError
+	assert.ErrorAs(t, returns[1].Error(), &panicError)
+	assert.Equal(t, 1, panicError.Index)
+	_, typed := returns[1].(*typedReturn[int])
+	assert.True(t, typed, "the indexed Return must keep its type")
+}
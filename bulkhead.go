@@ -0,0 +1,202 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrBulkheadFull error of a call rejected by a Bulkhead because its partition queue is full or the call
+	// waited more than the max wait
+	ErrBulkheadFull = errors.New("bulkhead full")
+)
+
+// BulkheadConfig limits of a Bulkhead partition
+type BulkheadConfig struct {
+	// MaxConcurrent maximum number of calls running at the same time, less than 1 is handled as 1
+	MaxConcurrent int
+	// MaxQueue maximum number of calls waiting for a free slot, further calls are rejected
+	MaxQueue int
+	// MaxWait maximum time a call wait for a free slot, zero wait until the context is done
+	MaxWait time.Duration
+}
+
+// BulkheadStats occupancy of a Bulkhead partition
+type BulkheadStats struct {
+	// InFlight number of calls running
+	InFlight int
+	// Waiting number of calls waiting for a free slot
+	Waiting int
+	// MaxConcurrent limit of calls running
+	MaxConcurrent int
+	// MaxQueue limit of calls waiting
+	MaxQueue int
+	// Rejected number of calls rejected since the partition was created
+	Rejected int
+}
+
+// bulkheadPartition semaphore of a partition, slots is buffered with capacity MaxConcurrent
+type bulkheadPartition struct {
+	mutex    sync.Mutex
+	name     string
+	config   BulkheadConfig
+	slots    chan struct{}
+	inFlight int
+	waiting  int
+	rejected int
+}
+
+func newBulkheadPartition(name string, config BulkheadConfig) *bulkheadPartition {
+	if config.MaxConcurrent < 1 {
+		config.MaxConcurrent = 1
+	}
+	if config.MaxQueue < 0 {
+		config.MaxQueue = 0
+	}
+	return &bulkheadPartition{name: name, config: config, slots: make(chan struct{}, config.MaxConcurrent)}
+}
+
+func (_self *bulkheadPartition) reject() error {
+	_self.rejected++
+	return fmt.Errorf("%w: partition %q", ErrBulkheadFull, _self.name)
+}
+
+// acquire take a slot, waiting in the queue when all slots are taken
+func (_self *bulkheadPartition) acquire(ctx context.Context) error {
+	_self.mutex.Lock()
+	select {
+	case _self.slots <- struct{}{}:
+		_self.inFlight++
+		_self.mutex.Unlock()
+		return nil
+	default:
+	}
+	if _self.waiting >= _self.config.MaxQueue {
+		err := _self.reject()
+		_self.mutex.Unlock()
+		return err
+	}
+	_self.waiting++
+	_self.mutex.Unlock()
+	var timeout <-chan time.Time
+	if _self.config.MaxWait > 0 {
+		timer := time.NewTimer(_self.config.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var err error
+	select {
+	case _self.slots <- struct{}{}:
+	case <-timeout:
+		err = ErrBulkheadFull
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+	}
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.waiting--
+	if err == ErrBulkheadFull {
+		return _self.reject()
+	}
+	if err == nil {
+		_self.inFlight++
+	}
+	return err
+}
+
+func (_self *bulkheadPartition) release() {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.inFlight--
+	<-_self.slots
+}
+
+func (_self *bulkheadPartition) stats() BulkheadStats {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	return BulkheadStats{
+		InFlight:      _self.inFlight,
+		Waiting:       _self.waiting,
+		MaxConcurrent: _self.config.MaxConcurrent,
+		MaxQueue:      _self.config.MaxQueue,
+		Rejected:      _self.rejected,
+	}
+}
+
+// Bulkhead cap the calls running at the same time per named partition, so a slow dependency can only take
+// the slots of its partition
+type Bulkhead struct {
+	mutex      sync.Mutex
+	defaults   BulkheadConfig
+	partitions map[string]*bulkheadPartition
+}
+
+// NewBulkhead create a Bulkhead whose partitions use defaults unless configured with SetPartition
+func NewBulkhead(defaults BulkheadConfig) *Bulkhead {
+	return &Bulkhead{defaults: defaults, partitions: map[string]*bulkheadPartition{}}
+}
+
+// SetPartition set the limits of the partition name, calls already running or waiting keep the previous limits
+func (_self *Bulkhead) SetPartition(name string, config BulkheadConfig) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.partitions[name] = newBulkheadPartition(name, config)
+}
+
+func (_self *Bulkhead) partition(name string) *bulkheadPartition {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	partition, ok := _self.partitions[name]
+	if !ok {
+		partition = newBulkheadPartition(name, _self.defaults)
+		_self.partitions[name] = partition
+	}
+	return partition
+}
+
+// WrapContext return a ContextFunction that call function in a slot of the partition, when the call is
+// rejected the Return contains an error wrapping ErrBulkheadFull, or the context cause when the context is
+// done while waiting, and function is not called
+func (_self *Bulkhead) WrapContext(partition string, function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		bulkheadPartition := _self.partition(partition)
+		if err := bulkheadPartition.acquire(ctx); err != nil {
+			return NewReturn(err)
+		}
+		defer bulkheadPartition.release()
+		return function(ctx)
+	}
+}
+
+// Wrap return a Function that call function in a slot of the partition
+func (_self *Bulkhead) Wrap(partition string, function Function) Function {
+	wrapped := _self.WrapContext(partition, func(ctx context.Context) Return {
+		return function()
+	})
+	return func() Return {
+		return wrapped(context.Background())
+	}
+}
+
+// Stats return the occupancy of the partition
+func (_self *Bulkhead) Stats(partition string) BulkheadStats {
+	return _self.partition(partition).stats()
+}
+
+// AllStats return the occupancy of every partition used or configured
+func (_self *Bulkhead) AllStats() map[string]BulkheadStats {
+	_self.mutex.Lock()
+	partitions := make([]*bulkheadPartition, 0, len(_self.partitions))
+	for _, partition := range _self.partitions {
+		partitions = append(partitions, partition)
+	}
+	_self.mutex.Unlock()
+	stats := make(map[string]BulkheadStats, len(partitions))
+	for _, partition := range partitions {
+		stats[partition.name] = partition.stats()
+	}
+	return stats
+}
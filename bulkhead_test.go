@@ -0,0 +1,122 @@
+package gauss
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// holdSlots call count functions of partition in goroutines that hold their slot until release is closed,
+// return once every function is running
+func holdSlots(bulkhead *Bulkhead, partition string, count int, release chan struct{}, group *sync.WaitGroup) {
+	var started sync.WaitGroup
+	started.Add(count)
+	group.Add(count)
+	for call := 0; call < count; call++ {
+		go func() {
+			defer group.Done()
+			bulkhead.Wrap(partition, func() Return {
+				started.Done()
+				<-release
+				return NewReturn(nil)
+			})()
+		}()
+	}
+	started.Wait()
+}
+
+// waitStats poll the stats of partition until condition is true
+func waitStats(bulkhead *Bulkhead, partition string, condition func(stats BulkheadStats) bool) {
+	for deadline := time.Now().Add(time.Second); !condition(bulkhead.Stats(partition)) && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func Test_GivenPartitionFull_WhenCall_ThenRejectWithErrBulkheadFull(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 2, MaxQueue: -1})
+	release := make(chan struct{})
+	var group sync.WaitGroup
+	holdSlots(bulkhead, "payments", 2, release, &group)
+	ret := bulkhead.Wrap("payments", func() Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})()
+	assert.ErrorIs(t, ret.Error(), ErrBulkheadFull)
+	assert.Equal(t, BulkheadStats{InFlight: 2, MaxConcurrent: 2, Rejected: 1}, bulkhead.Stats("payments"))
+	assert.Nil(t, bulkhead.Wrap("search", successFunction)().Error(), "partitions must be isolated")
+	close(release)
+	group.Wait()
+	assert.Equal(t, 0, bulkhead.Stats("payments").InFlight)
+}
+
+func Test_GivenQueuedCall_WhenSlotReleased_ThenRunCall(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 0, MaxQueue: 1})
+	release := make(chan struct{})
+	var group sync.WaitGroup
+	holdSlots(bulkhead, "payments", 1, release, &group)
+	returns := make(chan Return, 1)
+	go func() {
+		returns <- bulkhead.Wrap("payments", successFunction)()
+	}()
+	waitStats(bulkhead, "payments", func(stats BulkheadStats) bool {
+		return stats.Waiting == 1
+	})
+	assert.ErrorIs(t, bulkhead.Wrap("payments", successFunction)().Error(), ErrBulkheadFull, "queue is full")
+	close(release)
+	assert.Nil(t, (<-returns).Error())
+	group.Wait()
+	assert.Equal(t, BulkheadStats{MaxConcurrent: 1, MaxQueue: 1, Rejected: 1}, bulkhead.Stats("payments"))
+}
+
+func Test_GivenMaxWait_WhenNoSlotReleased_ThenRejectWithErrBulkheadFull(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{})
+	bulkhead.SetPartition("search", BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1, MaxWait: 10 * time.Millisecond})
+	release := make(chan struct{})
+	var group sync.WaitGroup
+	holdSlots(bulkhead, "search", 1, release, &group)
+	start := time.Now()
+	ret := bulkhead.Wrap("search", successFunction)()
+	assert.ErrorIs(t, ret.Error(), ErrBulkheadFull)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	close(release)
+	group.Wait()
+	assert.Equal(t, 1, bulkhead.AllStats()["search"].Rejected)
+}
+
+func Test_GivenContextCancelled_WhenWaiting_ThenReturnCause(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1})
+	release := make(chan struct{})
+	var group sync.WaitGroup
+	holdSlots(bulkhead, "payments", 1, release, &group)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ret := bulkhead.WrapContext("payments", successContextFunction)(ctx)
+	assert.ErrorIs(t, ret.Error(), context.DeadlineExceeded)
+	assert.Equal(t, 0, bulkhead.Stats("payments").Rejected, "a cancelled call is not rejected")
+	close(release)
+	group.Wait()
+}
+
+func Test_GivenBulkhead_WhenJoinCompleteAll_ThenRunAtMostMaxConcurrentFunctions(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 2, MaxQueue: 20})
+	var running, maxRunning int32
+	funcs := make([]Function, 10)
+	for index := range funcs {
+		funcs[index] = bulkhead.Wrap("payments", concurrencyFunction(&running, &maxRunning))
+	}
+	_, ok := JoinCompleteAll(funcs...)
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), maxRunning)
+}
+
+func Test_GivenPanicFunction_WhenCall_ThenReleaseSlot(t *testing.T) {
+	bulkhead := NewBulkhead(BulkheadConfig{MaxConcurrent: 1})
+	_, err := JoinFailOnAnyError(bulkhead.Wrap("payments", panicFunction))
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+	assert.Equal(t, 0, bulkhead.Stats("payments").InFlight)
+	assert.Nil(t, bulkhead.Wrap("payments", successFunction)().Error())
+}
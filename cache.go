@@ -0,0 +1,208 @@
+package gauss
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheConfig expiration and size of a Cache
+type CacheConfig struct {
+	// TTL time a value is fresh after it is loaded, zero never expire
+	TTL time.Duration
+	// ErrorTTL time a Return with error is cached, zero does not cache errors
+	ErrorTTL time.Duration
+	// MaxSize maximum number of entries, the least recently used entry is evicted, zero is unbounded
+	MaxSize int
+	// RefreshAhead a value got during the RefreshAhead before its expiration is refreshed in background
+	RefreshAhead time.Duration
+	// MaxStale time an expired value is still served while it is refreshed in background, zero load expired
+	// values in the caller goroutine
+	MaxStale time.Duration
+}
+
+// CacheStats counters of a Cache
+type CacheStats struct {
+	// Hits number of Get served with a fresh entry
+	Hits int64
+	// StaleHits number of Get served with an expired value while it is refreshed
+	StaleHits int64
+	// Misses number of Get that wait a load
+	Misses int64
+	// Loads number of loader calls
+	Loads int64
+	// LoadErrors number of loader calls that return an error
+	LoadErrors int64
+	// Evictions number of entries evicted because the cache is full
+	Evictions int64
+	// TotalLoadTime time spent in loader calls
+	TotalLoadTime time.Duration
+}
+
+// AverageLoadTime return the average time of a loader call
+func (_self CacheStats) AverageLoadTime() time.Duration {
+	if _self.Loads == 0 {
+		return 0
+	}
+	return _self.TotalLoadTime / time.Duration(_self.Loads)
+}
+
+type cacheEntry struct {
+	key        string
+	ret        Return
+	expiresAt  time.Time
+	refreshing bool
+}
+
+// cacheState freshness of an entry
+type cacheState int
+
+const (
+	cacheFresh cacheState = iota
+	cacheRefreshAhead
+	cacheStale
+	cacheExpired
+)
+
+// Cache loading cache of Return by key, concurrent loads of a key are collapsed into one loader call
+type Cache struct {
+	mutex   sync.Mutex
+	config  CacheConfig
+	entries map[string]*list.Element
+	lru     *list.List
+	group   *Group
+	stats   CacheStats
+}
+
+// NewCache create an empty Cache
+func NewCache(config CacheConfig) *Cache {
+	return &Cache{config: config, entries: map[string]*list.Element{}, lru: list.New(), group: NewGroup()}
+}
+
+func (_self *Cache) state(entry *cacheEntry, now time.Time) cacheState {
+	switch {
+	case entry.expiresAt.IsZero():
+		return cacheFresh
+	case entry.ret.Error() != nil:
+		// errors expire without refresh
+		if now.Before(entry.expiresAt) {
+			return cacheFresh
+		}
+		return cacheExpired
+	case now.Before(entry.expiresAt.Add(-_self.config.RefreshAhead)):
+		return cacheFresh
+	case now.Before(entry.expiresAt):
+		return cacheRefreshAhead
+	case now.Before(entry.expiresAt.Add(_self.config.MaxStale)):
+		return cacheStale
+	}
+	return cacheExpired
+}
+
+// Get return the cached Return of key, or call loader and cache its Return
+func (_self *Cache) Get(key string, loader Function) Return {
+	return _self.GetContext(context.Background(), key, contextFunction(loader))
+}
+
+// GetContext return the cached Return of key, or call loader and cache its Return. The caller stop waiting
+// when the context is done, the load is cancelled only when every caller waiting it left. Values close to
+// expire or expired less than MaxStale ago are returned and refreshed in background
+func (_self *Cache) GetContext(ctx context.Context, key string, loader ContextFunction) Return {
+	_self.mutex.Lock()
+	if element, ok := _self.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		state := _self.state(entry, time.Now())
+		if state != cacheExpired {
+			_self.lru.MoveToFront(element)
+			if state == cacheStale {
+				_self.stats.StaleHits++
+			} else {
+				_self.stats.Hits++
+			}
+			refresh := state != cacheFresh && !entry.refreshing
+			entry.refreshing = entry.refreshing || refresh
+			_self.mutex.Unlock()
+			if refresh {
+				_self.group.DoChanContext(context.Background(), key, _self.loadFunction(key, loader))
+			}
+			return entry.ret
+		}
+	}
+	_self.stats.Misses++
+	_self.mutex.Unlock()
+	return _self.group.DoContext(ctx, key, _self.loadFunction(key, loader))
+}
+
+// loadFunction return a function that call loader and store its Return
+func (_self *Cache) loadFunction(key string, loader ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		start := time.Now()
+		ret := runFunction(ctx, -1, loader)
+		_self.store(key, ret, time.Since(start), ctx.Err() != nil)
+		return ret
+	}
+}
+
+// store cache the Return of a load. A cancelled load keep the current entry, an error keep it too when errors
+// are not cached or the entry has a value that can still be served, so a failed refresh serve the stale value
+func (_self *Cache) store(key string, ret Return, loadTime time.Duration, cancelled bool) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.stats.Loads++
+	_self.stats.TotalLoadTime += loadTime
+	ttl := _self.config.TTL
+	if ret.Error() != nil {
+		_self.stats.LoadErrors++
+		ttl = _self.config.ErrorTTL
+	}
+	element, exists := _self.entries[key]
+	servable := exists && element.Value.(*cacheEntry).ret.Error() == nil &&
+		_self.state(element.Value.(*cacheEntry), time.Now()) != cacheExpired
+	if cancelled || (ret.Error() != nil && (ttl <= 0 || servable)) {
+		if exists {
+			element.Value.(*cacheEntry).refreshing = false
+		}
+		return
+	}
+	entry := &cacheEntry{key: key, ret: ret}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	if exists {
+		element.Value = entry
+		_self.lru.MoveToFront(element)
+		return
+	}
+	_self.entries[key] = _self.lru.PushFront(entry)
+	for _self.config.MaxSize > 0 && _self.lru.Len() > _self.config.MaxSize {
+		oldest := _self.lru.Back()
+		_self.lru.Remove(oldest)
+		delete(_self.entries, oldest.Value.(*cacheEntry).key)
+		_self.stats.Evictions++
+	}
+}
+
+// Invalidate remove the entry of key, a load already running still store its Return
+func (_self *Cache) Invalidate(key string) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if element, ok := _self.entries[key]; ok {
+		_self.lru.Remove(element)
+		delete(_self.entries, key)
+	}
+}
+
+// Len return the number of entries, including expired entries not evicted yet
+func (_self *Cache) Len() int {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	return _self.lru.Len()
+}
+
+// Stats return a snapshot of the counters
+func (_self *Cache) Stats() CacheStats {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	return _self.stats
+}
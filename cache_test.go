@@ -0,0 +1,185 @@
+package gauss
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counterLoader return a loader that return the number of its calls
+func counterLoader(calls *int32) Function {
+	return func() Return {
+		return NewReturn(nil, int(atomic.AddInt32(calls, 1)))
+	}
+}
+
+// waitValue poll cache until key has value
+func waitValue(cache *Cache, key string, value int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cache.Get(key, successFunction).ReturnValues()[0] == value {
+			return
+		}
+	}
+}
+
+func Test_GivenCachedKey_WhenGet_ThenReturnCachedValueWithoutLoad(t *testing.T) {
+	cache := NewCache(CacheConfig{})
+	assert.Equal(t, time.Duration(0), cache.Stats().AverageLoadTime())
+	var calls int32
+	loader := counterLoader(&calls)
+	assert.Equal(t, 1, cache.Get("key", func() Return {
+		time.Sleep(time.Millisecond)
+		return loader()
+	}).ReturnValues()[0])
+	assert.Equal(t, 1, cache.Get("key", loader).ReturnValues()[0])
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(1), stats.Loads)
+	assert.GreaterOrEqual(t, stats.AverageLoadTime(), time.Millisecond)
+}
+
+func Test_GivenExpiredKey_WhenGet_ThenLoadAgain(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: 20 * time.Millisecond})
+	var calls int32
+	cache.Get("key", counterLoader(&calls))
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 2, cache.Get("key", counterLoader(&calls)).ReturnValues()[0])
+}
+
+func Test_GivenLoaderError_WhenGet_ThenCacheErrorOnlyWithErrorTTL(t *testing.T) {
+	cache := NewCache(CacheConfig{})
+	cache.Get("key", errorFunction)
+	cache.Get("key", errorFunction)
+	assert.Equal(t, int64(2), cache.Stats().LoadErrors)
+	cache = NewCache(CacheConfig{TTL: time.Second, ErrorTTL: 20 * time.Millisecond, RefreshAhead: time.Second})
+	cache.Get("key", errorFunction)
+	assert.ErrorIs(t, cache.Get("key", successFunction).Error(), errNormal)
+	assert.Equal(t, int64(1), cache.Stats().Loads, "an error must not be refreshed ahead")
+	time.Sleep(30 * time.Millisecond)
+	assert.Nil(t, cache.Get("key", successFunction).Error())
+}
+
+func Test_GivenFullCache_WhenLoadNewKey_ThenEvictLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(CacheConfig{MaxSize: 2})
+	var calls int32
+	cache.Get("a", counterLoader(&calls))
+	cache.Get("b", counterLoader(&calls))
+	cache.Get("a", counterLoader(&calls))
+	cache.Get("c", counterLoader(&calls))
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, int64(1), cache.Stats().Evictions)
+	assert.Equal(t, 1, cache.Get("a", counterLoader(&calls)).ReturnValues()[0])
+	assert.Equal(t, 4, cache.Get("b", counterLoader(&calls)).ReturnValues()[0])
+}
+
+func Test_GivenKeyCloseToExpire_WhenGet_ThenReturnValueAndRefreshInBackground(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: time.Second, RefreshAhead: 990 * time.Millisecond})
+	var calls int32
+	cache.Get("key", counterLoader(&calls))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, cache.Get("key", counterLoader(&calls)).ReturnValues()[0])
+	waitValue(cache, "key", 2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenExpiredKeyWithMaxStale_WhenGet_ThenReturnStaleValueAndRefresh(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: 10 * time.Millisecond, MaxStale: time.Second})
+	var calls int32
+	cache.Get("key", counterLoader(&calls))
+	time.Sleep(20 * time.Millisecond)
+	release := make(chan struct{})
+	slowLoader := func() Return {
+		<-release
+		return counterLoader(&calls)()
+	}
+	assert.Equal(t, 1, cache.Get("key", slowLoader).ReturnValues()[0])
+	assert.Equal(t, 1, cache.Get("key", slowLoader).ReturnValues()[0], "a running refresh must not be started again")
+	assert.Equal(t, int64(2), cache.Stats().StaleHits)
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 2, cache.Get("key", successFunction).ReturnValues()[0])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenFailingRefresh_WhenGet_ThenKeepStaleValue(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: 10 * time.Millisecond, MaxStale: time.Second})
+	cache.Get("key", successFunction)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("key", errorFunction)
+	for deadline := time.Now().Add(time.Second); cache.Stats().LoadErrors == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(5 * time.Millisecond)
+	var calls int32
+	assert.Equal(t, successValue, cache.Get("key", counterLoader(&calls)).ReturnValues()[0])
+	waitValue(cache, "key", 1)
+}
+
+func Test_GivenFailingRefreshWithErrorTTL_WhenGet_ThenKeepStaleValue(t *testing.T) {
+	cache := NewCache(CacheConfig{TTL: 10 * time.Millisecond, ErrorTTL: time.Second, MaxStale: 100 * time.Millisecond})
+	cache.Get("key", successFunction)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, successValue, cache.Get("key", errorFunction).ReturnValues()[0])
+	for deadline := time.Now().Add(time.Second); cache.Stats().LoadErrors == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(5 * time.Millisecond)
+	ret := cache.Get("key", errorFunction)
+	assert.Nil(t, ret.Error(), "a failed refresh must not replace a stale value")
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	time.Sleep(150 * time.Millisecond)
+	assert.ErrorIs(t, cache.Get("key", errorFunction).Error(), errNormal)
+	assert.ErrorIs(t, cache.Get("key", successFunction).Error(), errNormal, "an error without value to serve is cached")
+}
+
+func Test_GivenConcurrentMisses_WhenGet_ThenLoadOnce(t *testing.T) {
+	cache := NewCache(CacheConfig{})
+	var calls int32
+	var group sync.WaitGroup
+	for call := 0; call < 5; call++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			cache.Get("key", func() Return {
+				time.Sleep(20 * time.Millisecond)
+				return counterLoader(&calls)()
+			})
+		}()
+	}
+	group.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenCallerLeave_WhenGetContext_ThenLoadIsCancelledAndNotCached(t *testing.T) {
+	cache := NewCache(CacheConfig{ErrorTTL: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	started, causes := make(chan struct{}), make(chan error, 1)
+	ret := cache.GetContext(ctx, "key", causeContextFunction(started, causes))
+	assert.Equal(t, StatusTimedOut, StatusOf(ret))
+	<-causes
+	for deadline := time.Now().Add(time.Second); cache.Stats().Loads == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 0, cache.Len())
+}
+
+func Test_GivenPanicLoader_WhenGet_ThenReturnPanicError(t *testing.T) {
+	cache := NewCache(CacheConfig{})
+	assert.Equal(t, StatusPanicked, StatusOf(cache.Get("key", panicFunction)))
+	assert.Equal(t, 0, cache.Len())
+}
+
+func Test_GivenInvalidatedKey_WhenGet_ThenLoadAgain(t *testing.T) {
+	cache := NewCache(CacheConfig{})
+	var calls int32
+	cache.Get("key", counterLoader(&calls))
+	cache.Invalidate("key")
+	cache.Invalidate("missing")
+	assert.Equal(t, 2, cache.Get("key", counterLoader(&calls)).ReturnValues()[0])
+}
@@ -0,0 +1,170 @@
+package gauss
+
+import (
+	"context"
+)
+
+// chain return a future completed with the Return of stage called with the Return of source. Cancel the
+// returned future cancel source too, if the stage panic the Return contains a *PanicError
+func chain(source *Future, stage func(ctx context.Context, ret Return) Return) *Future {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	future := &Future{done: make(chan struct{}), cancel: func(cause error) {
+		cancel(cause)
+		source.cancelWithCause(cause)
+	}}
+	go func() {
+		ret := source.Await()
+		if ctx.Err() == nil {
+			ret = runFunction(ctx, -1, func(ctx context.Context) Return {
+				return stage(ctx, ret)
+			})
+		}
+		future.complete(ret)
+		cancel(nil)
+	}()
+	return future
+}
+
+// Then return a future completed with the Return of function called with the return values of the future
+// when it success, when the future fail the returned future is completed with the same Return
+func (_self *Future) Then(function func(returnValues []interface{}) Return) *Future {
+	return chain(_self, func(ctx context.Context, ret Return) Return {
+		if ret.Error() != nil {
+			return ret
+		}
+		return function(ret.ReturnValues())
+	})
+}
+
+// Catch return a future completed with the Return of function called with the error of the future
+// when it fail, cancelled futures and panics are errors too. When the future success the returned
+// future is completed with the same Return
+func (_self *Future) Catch(function func(err error) Return) *Future {
+	return chain(_self, func(ctx context.Context, ret Return) Return {
+		if ret.Error() == nil {
+			return ret
+		}
+		return function(ret.Error())
+	})
+}
+
+// Finally return a future completed with the Return of the future after call function with it
+func (_self *Future) Finally(function func(ret Return)) *Future {
+	return chain(_self, func(ctx context.Context, ret Return) Return {
+		function(ret)
+		return ret
+	})
+}
+
+// Map return a future with the return values of the future transformed by function when it success
+func (_self *Future) Map(function func(returnValues []interface{}) []interface{}) *Future {
+	return _self.Then(func(returnValues []interface{}) Return {
+		return NewReturn(nil, function(returnValues)...)
+	})
+}
+
+// FlatMap return a future completed with the Return of the future returned by function called with the
+// return values of the future when it success, the inner future is cancelled with the returned future
+func (_self *Future) FlatMap(function func(returnValues []interface{}) *Future) *Future {
+	return chain(_self, func(ctx context.Context, ret Return) Return {
+		if ret.Error() != nil {
+			return ret
+		}
+		return function(ret.ReturnValues()).ContextFunction()(ctx)
+	})
+}
+
+// TypedFuture typed handle of a TypedFunction running asynchronously
+type TypedFuture[T any] struct {
+	future *Future
+}
+
+// GoTyped run function asynchronously and return a TypedFuture to await its Result
+func GoTyped[T any](function TypedFunction[T]) *TypedFuture[T] {
+	return &TypedFuture[T]{future: Go(function.Function())}
+}
+
+// GoTypedContext run function asynchronously and return a TypedFuture to await its Result, the context
+// received by function is cancelled when the TypedFuture is cancelled
+func GoTypedContext[T any](ctx context.Context, function TypedContextFunction[T]) *TypedFuture[T] {
+	return &TypedFuture[T]{future: GoContext(ctx, function.ContextFunction())}
+}
+
+// Future return the untyped Future
+func (_self *TypedFuture[T]) Future() *Future {
+	return _self.future
+}
+
+// Await typed version of Future.Await
+func (_self *TypedFuture[T]) Await() Result[T] {
+	return ResultOf[T](_self.future.Await())
+}
+
+// AwaitContext typed version of Future.AwaitContext
+func (_self *TypedFuture[T]) AwaitContext(ctx context.Context) Result[T] {
+	return ResultOf[T](_self.future.AwaitContext(ctx))
+}
+
+// Done return a channel closed when the future complete
+func (_self *TypedFuture[T]) Done() <-chan struct{} {
+	return _self.future.Done()
+}
+
+// TryGet typed version of Future.TryGet
+func (_self *TypedFuture[T]) TryGet() (Result[T], bool) {
+	ret, completed := _self.future.TryGet()
+	return ResultOf[T](ret), completed
+}
+
+// Cancel typed version of Future.Cancel
+func (_self *TypedFuture[T]) Cancel() bool {
+	return _self.future.Cancel()
+}
+
+// Catch typed version of Future.Catch
+func (_self *TypedFuture[T]) Catch(function func(err error) (T, error)) *TypedFuture[T] {
+	return &TypedFuture[T]{future: chain(_self.future, func(ctx context.Context, ret Return) Return {
+		if ret.Error() == nil {
+			return ret
+		}
+		return TypedFunction[T](func() (T, error) {
+			return function(ret.Error())
+		}).Function()()
+	})}
+}
+
+// Finally typed version of Future.Finally
+func (_self *TypedFuture[T]) Finally(function func(result Result[T])) *TypedFuture[T] {
+	return &TypedFuture[T]{future: _self.future.Finally(func(ret Return) {
+		function(ResultOf[T](ret))
+	})}
+}
+
+// ThenTyped typed version of Future.Then
+func ThenTyped[T, U any](source *TypedFuture[T], function func(value T) (U, error)) *TypedFuture[U] {
+	return &TypedFuture[U]{future: chain(source.future, func(ctx context.Context, ret Return) Return {
+		if ret.Error() != nil {
+			return ret
+		}
+		return TypedFunction[U](func() (U, error) {
+			return function(ResultOf[T](ret).Value())
+		}).Function()()
+	})}
+}
+
+// MapTyped typed version of Future.Map
+func MapTyped[T, U any](source *TypedFuture[T], function func(value T) U) *TypedFuture[U] {
+	return ThenTyped(source, func(value T) (U, error) {
+		return function(value), nil
+	})
+}
+
+// FlatMapTyped typed version of Future.FlatMap
+func FlatMapTyped[T, U any](source *TypedFuture[T], function func(value T) *TypedFuture[U]) *TypedFuture[U] {
+	return &TypedFuture[U]{future: chain(source.future, func(ctx context.Context, ret Return) Return {
+		if ret.Error() != nil {
+			return ret
+		}
+		return function(ResultOf[T](ret).Value()).future.ContextFunction()(ctx)
+	})}
+}
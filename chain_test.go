@@ -0,0 +1,174 @@
+package gauss
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Future chaining tests
+
+func Test_GivenSuccessFuture_WhenThen_ThenCallFunctionWithReturnValues(t *testing.T) {
+	ret := Go(successFunction).Then(func(returnValues []interface{}) Return {
+		return NewReturn(nil, returnValues[0].(string)+"-profile")
+	}).Await()
+	assert.Equal(t, successValue+"-profile", ret.ReturnValues()[0])
+}
+
+func Test_GivenErrorFuture_WhenThenAndCatch_ThenSkipThenAndCallCatch(t *testing.T) {
+	ret := Go(errorFunction).Then(func(returnValues []interface{}) Return {
+		assert.True(t, false, "Then must no be called when the future fail")
+		return NewReturn(nil)
+	}).Catch(func(err error) Return {
+		assert.ErrorIs(t, err, errNormal)
+		return NewReturn(nil, "cache")
+	}).Await()
+	assert.Equal(t, "cache", ret.ReturnValues()[0])
+}
+
+func Test_GivenSuccessFuture_WhenCatch_ThenReturnSameReturn(t *testing.T) {
+	ret := Go(successFunction).Catch(func(err error) Return {
+		assert.True(t, false, "Catch must no be called when the future success")
+		return NewReturn(err)
+	}).Await()
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+}
+
+func Test_GivenThenDoPanic_WhenAwait_ThenReturnPanicError(t *testing.T) {
+	ret := Go(successFunction).Then(func(returnValues []interface{}) Return {
+		panic("panic")
+	}).Await()
+	assert.Equal(t, StatusPanicked, StatusOf(ret))
+}
+
+func Test_GivenFuture_WhenFinally_ThenCallFunctionAndReturnSameReturn(t *testing.T) {
+	called := false
+	ret := Go(errorFunction).Finally(func(ret Return) {
+		called = true
+	}).Await()
+	assert.True(t, called)
+	assert.ErrorIs(t, ret.Error(), errNormal)
+}
+
+func Test_GivenSuccessFuture_WhenMap_ThenReturnMappedValues(t *testing.T) {
+	ret := Go(successFunction).Map(func(returnValues []interface{}) []interface{} {
+		return []interface{}{len(returnValues[0].(string))}
+	}).Await()
+	assert.Equal(t, len(successValue), ret.ReturnValues()[0])
+}
+
+func Test_GivenSuccessFuture_WhenFlatMap_ThenReturnInnerFutureReturn(t *testing.T) {
+	ret := Go(successFunction).FlatMap(func(returnValues []interface{}) *Future {
+		return Go(func() Return {
+			return NewReturn(nil, returnValues[0], "inner")
+		})
+	}).Await()
+	assert.Equal(t, []interface{}{successValue, "inner"}, ret.ReturnValues())
+}
+
+func Test_GivenErrorFuture_WhenFlatMap_ThenReturnSameError(t *testing.T) {
+	ret := Go(errorFunction).FlatMap(func(returnValues []interface{}) *Future {
+		assert.True(t, false, "FlatMap must no be called when the future fail")
+		return Go(successFunction)
+	}).Await()
+	assert.ErrorIs(t, ret.Error(), errNormal)
+}
+
+func Test_GivenChainedFuture_WhenCancel_ThenCancelSource(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	source := GoContext(context.Background(), causeContextFunction(started, causes))
+	chained := source.Then(func(returnValues []interface{}) Return {
+		assert.True(t, false, "Then must no be called when the future is cancelled")
+		return NewReturn(nil)
+	})
+	<-started
+	assert.True(t, chained.Cancel())
+	assert.ErrorIs(t, <-causes, ErrCancelled)
+	assert.Equal(t, StatusCancelled, StatusOf(source.Await()))
+	assert.Equal(t, StatusCancelled, StatusOf(chained.Await()))
+}
+
+func Test_GivenCancelledSource_WhenCatch_ThenCallCatchWithErrCancelled(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	source := GoContext(context.Background(), causeContextFunction(started, causes))
+	<-started
+	source.Cancel()
+	ret := source.Catch(func(err error) Return {
+		assert.ErrorIs(t, err, ErrCancelled)
+		return NewReturn(nil, "fallback")
+	}).Await()
+	assert.Equal(t, "fallback", ret.ReturnValues()[0])
+}
+
+// TypedFuture tests
+
+func Test_GivenTypedFuture_WhenThenTypedAndMapTyped_ThenReturnTypedValue(t *testing.T) {
+	token := GoTyped(typedSuccessFunction)
+	profile := ThenTyped(token, func(value int) (string, error) {
+		return "profile-" + strconv.Itoa(value), nil
+	})
+	length := MapTyped(profile, func(value string) int {
+		return len(value)
+	})
+	assert.Equal(t, "profile-1", profile.Await().Value())
+	assert.Equal(t, len("profile-1"), length.Await().Value())
+}
+
+func Test_GivenErrorTypedFuture_WhenThenTypedAndCatch_ThenReturnFallbackValue(t *testing.T) {
+	profile := ThenTyped(GoTyped(typedErrorFunction), func(value int) (string, error) {
+		assert.True(t, false, "ThenTyped must no be called when the future fail")
+		return "", nil
+	}).Catch(func(err error) (string, error) {
+		assert.ErrorIs(t, err, errNormal)
+		return "cache", nil
+	})
+	assert.Equal(t, "cache", profile.Await().Value())
+}
+
+func Test_GivenSuccessTypedFuture_WhenCatch_ThenReturnValue(t *testing.T) {
+	result := GoTyped(typedSuccessFunction).Catch(func(err error) (int, error) {
+		return 0, err
+	}).Await()
+	assert.Equal(t, 1, result.Value())
+}
+
+func Test_GivenTypedFuture_WhenFlatMapTypedAndFinally_ThenReturnInnerValue(t *testing.T) {
+	var finallyResult Result[string]
+	result := FlatMapTyped(GoTyped(typedSuccessFunction), func(value int) *TypedFuture[string] {
+		return GoTypedContext(context.Background(), func(ctx context.Context) (string, error) {
+			return strconv.Itoa(value + 1), nil
+		})
+	}).Finally(func(result Result[string]) {
+		finallyResult = result
+	}).Await()
+	assert.Equal(t, "2", result.Value())
+	assert.Equal(t, "2", finallyResult.Value())
+}
+
+func Test_GivenErrorTypedFuture_WhenFlatMapTyped_ThenReturnError(t *testing.T) {
+	result := FlatMapTyped(GoTyped(typedErrorFunction), func(value int) *TypedFuture[string] {
+		return GoTyped(typedStringFunction)
+	}).Await()
+	assert.ErrorIs(t, result.Err(), errNormal)
+	assert.Equal(t, "", result.Value())
+}
+
+func Test_GivenTypedFuture_WhenTryGetAwaitContextAndCancel_ThenBehaveAsFuture(t *testing.T) {
+	future := GoTypedContext(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	_, completed := future.TryGet()
+	assert.False(t, completed)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, future.AwaitContext(ctx).Err(), ErrCancelled)
+	assert.True(t, future.Cancel())
+	<-future.Done()
+	result, completed := future.TryGet()
+	assert.True(t, completed)
+	assert.ErrorIs(t, result.Err(), ErrCancelled)
+	assert.Equal(t, StatusCancelled, StatusOf(future.Future().Await()))
+}
@@ -0,0 +1,357 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCircuitOpen error of a call rejected by a CircuitBreaker
+	ErrCircuitOpen = errors.New("circuit open")
+)
+
+// CircuitState state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed calls are allowed and their outcome recorded
+	CircuitClosed CircuitState = iota
+	// CircuitOpen calls are rejected until the open duration elapse
+	CircuitOpen
+	// CircuitHalfOpen a limited number of probe calls are allowed to decide if the circuit close or open again
+	CircuitHalfOpen
+)
+
+func (_self CircuitState) String() string {
+	switch _self {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitOpenError error of a call rejected by a CircuitBreaker, errors.Is(err, ErrCircuitOpen) is true
+type CircuitOpenError struct {
+	// State of the circuit when the call was rejected
+	State CircuitState
+	// RetryAfter time until the circuit allow probe calls, zero when the circuit is half-open
+	RetryAfter time.Duration
+}
+
+func (_self *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit %s: retry after %v", _self.State, _self.RetryAfter)
+}
+
+func (_self *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// circuitRecord outcome of a call in the window of a closed circuit
+type circuitRecord struct {
+	time   time.Time
+	failed bool
+}
+
+// circuitOutcome outcome of a call, ignored calls are cancelled calls that are not recorded
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored
+)
+
+// circuitChange state change notified to the hooks once the lock is released
+type circuitChange struct {
+	from CircuitState
+	to   CircuitState
+}
+
+// CircuitBreaker reject calls to a failing dependency. A closed circuit open when the consecutive failures
+// or the failure rate of the calls in the window reach their threshold, after the open duration the circuit
+// is half-open and allow probe calls, it close when every probe success and open again when any probe fail
+type CircuitBreaker struct {
+	mutex               sync.Mutex
+	state               CircuitState
+	generation          uint64
+	openedAt            time.Time
+	records             []circuitRecord
+	consecutiveFailures int
+	probesInFlight      int
+	probeSuccesses      int
+
+	consecutiveFailureThreshold int
+	failureRateThreshold        float64
+	minimumCalls                int
+	windowSize                  int
+	windowDuration              time.Duration
+	openDuration                time.Duration
+	halfOpenProbes              int
+	isFailure                   func(err error) bool
+	hooks                       []func(from CircuitState, to CircuitState)
+}
+
+type CircuitBreakerOption func(breaker *CircuitBreaker)
+
+// WithConsecutiveFailures open the circuit after threshold consecutive failures, default 5, 0 disable it
+func WithConsecutiveFailures(threshold int) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.consecutiveFailureThreshold = threshold
+	}
+}
+
+// WithFailureRate open the circuit when the window has at least minimumCalls calls and the rate of failed
+// calls, between 0 and 1, is greater or equal than threshold. Disabled by default
+func WithFailureRate(threshold float64, minimumCalls int) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.failureRateThreshold = threshold
+		breaker.minimumCalls = minimumCalls
+	}
+}
+
+// WithCountWindow keep the last size calls in the window, default 100
+func WithCountWindow(size int) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.windowSize = size
+	}
+}
+
+// WithTimeWindow keep the calls completed during the last duration in the window, calls are also limited
+// by the count window
+func WithTimeWindow(duration time.Duration) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.windowDuration = duration
+	}
+}
+
+// WithOpenDuration set the time the circuit reject calls before allow probes, default 30 seconds
+func WithOpenDuration(duration time.Duration) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.openDuration = duration
+	}
+}
+
+// WithHalfOpenProbes set the number of concurrent probe calls allowed by a half-open circuit, all of them
+// must success to close the circuit, default 1
+func WithHalfOpenProbes(probes int) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.halfOpenProbes = probes
+	}
+}
+
+// WithFailurePredicate set the function that decide if the error of a call is a failure, by default every
+// error. The predicate is only called with non nil errors, a call without error is a success. A call that
+// is not a failure and return an error because it was cancelled, e.g. by a join, is ignored: it is not
+// recorded and release its half-open probe slot
+func WithFailurePredicate(isFailure func(err error) bool) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.isFailure = isFailure
+	}
+}
+
+// WithStateChangeHook add a function called after each state change, hooks are called without holding
+// the breaker lock
+func WithStateChangeHook(hook func(from CircuitState, to CircuitState)) CircuitBreakerOption {
+	return func(breaker *CircuitBreaker) {
+		breaker.hooks = append(breaker.hooks, hook)
+	}
+}
+
+// NewCircuitBreaker create a closed CircuitBreaker
+func NewCircuitBreaker(options ...CircuitBreakerOption) *CircuitBreaker {
+	breaker := &CircuitBreaker{
+		consecutiveFailureThreshold: 5,
+		windowSize:                  100,
+		openDuration:                30 * time.Second,
+		halfOpenProbes:              1,
+	}
+	for _, option := range options {
+		option(breaker)
+	}
+	if breaker.windowSize < 1 {
+		breaker.windowSize = 1
+	}
+	if breaker.halfOpenProbes < 1 {
+		breaker.halfOpenProbes = 1
+	}
+	return breaker
+}
+
+// State return the current state, an open circuit whose open duration elapsed is reported as half-open
+func (_self *CircuitBreaker) State() CircuitState {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if _self.state == CircuitOpen && time.Since(_self.openedAt) >= _self.openDuration {
+		return CircuitHalfOpen
+	}
+	return _self.state
+}
+
+// transition change the state and start a new generation, calls admitted in a previous generation are
+// not recorded
+func (_self *CircuitBreaker) transition(to CircuitState, changes []circuitChange) []circuitChange {
+	changes = append(changes, circuitChange{from: _self.state, to: to})
+	_self.state = to
+	_self.generation++
+	_self.records = nil
+	_self.consecutiveFailures = 0
+	_self.probesInFlight = 0
+	_self.probeSuccesses = 0
+	if to == CircuitOpen {
+		_self.openedAt = time.Now()
+	}
+	return changes
+}
+
+func (_self *CircuitBreaker) notify(changes []circuitChange) {
+	for _, change := range changes {
+		for _, hook := range _self.hooks {
+			hook(change.from, change.to)
+		}
+	}
+}
+
+// acquire admit a call and return its generation, or a *CircuitOpenError when the call is rejected
+func (_self *CircuitBreaker) acquire() (uint64, error) {
+	_self.mutex.Lock()
+	var changes []circuitChange
+	defer func() {
+		_self.mutex.Unlock()
+		_self.notify(changes)
+	}()
+	if _self.state == CircuitOpen {
+		if elapsed := time.Since(_self.openedAt); elapsed < _self.openDuration {
+			return 0, &CircuitOpenError{State: CircuitOpen, RetryAfter: _self.openDuration - elapsed}
+		}
+		changes = _self.transition(CircuitHalfOpen, changes)
+	}
+	if _self.state == CircuitHalfOpen {
+		if _self.probesInFlight+_self.probeSuccesses >= _self.halfOpenProbes {
+			return 0, &CircuitOpenError{State: CircuitHalfOpen}
+		}
+		_self.probesInFlight++
+	}
+	return _self.generation, nil
+}
+
+// outcomeOf classify the error of a call made with ctx, a call is cancelled when it return an error and
+// the error or its context is cancelled
+func (_self *CircuitBreaker) outcomeOf(ctx context.Context, err error) circuitOutcome {
+	if err == nil {
+		return circuitSuccess
+	}
+	cancelled := errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)
+	switch {
+	case _self.isFailure != nil && _self.isFailure(err):
+		return circuitFailure
+	case cancelled:
+		return circuitIgnored
+	case _self.isFailure == nil:
+		return circuitFailure
+	}
+	return circuitSuccess
+}
+
+// record record the outcome of a call admitted in generation and change the state when a threshold is
+// reached, an ignored call only release its probe slot
+func (_self *CircuitBreaker) record(generation uint64, outcome circuitOutcome) {
+	_self.mutex.Lock()
+	var changes []circuitChange
+	defer func() {
+		_self.mutex.Unlock()
+		_self.notify(changes)
+	}()
+	if generation != _self.generation {
+		return
+	}
+	failed := outcome == circuitFailure
+	if _self.state == CircuitHalfOpen {
+		_self.probesInFlight--
+		if outcome == circuitIgnored {
+			return
+		}
+		if failed {
+			changes = _self.transition(CircuitOpen, changes)
+			return
+		}
+		_self.probeSuccesses++
+		if _self.probeSuccesses >= _self.halfOpenProbes {
+			changes = _self.transition(CircuitClosed, changes)
+		}
+		return
+	}
+	if outcome == circuitIgnored {
+		return
+	}
+	now := time.Now()
+	_self.records = append(_self.records, circuitRecord{time: now, failed: failed})
+	if len(_self.records) > _self.windowSize {
+		_self.records = _self.records[len(_self.records)-_self.windowSize:]
+	}
+	if _self.windowDuration > 0 {
+		start := 0
+		for start < len(_self.records) && now.Sub(_self.records[start].time) > _self.windowDuration {
+			start++
+		}
+		_self.records = _self.records[start:]
+	}
+	if !failed {
+		_self.consecutiveFailures = 0
+		return
+	}
+	_self.consecutiveFailures++
+	if _self.consecutiveFailureThreshold > 0 && _self.consecutiveFailures >= _self.consecutiveFailureThreshold {
+		changes = _self.transition(CircuitOpen, changes)
+		return
+	}
+	if _self.failureRateThreshold > 0 && len(_self.records) >= _self.minimumCalls {
+		failures := 0
+		for _, record := range _self.records {
+			if record.failed {
+				failures++
+			}
+		}
+		if float64(failures)/float64(len(_self.records)) >= _self.failureRateThreshold {
+			changes = _self.transition(CircuitOpen, changes)
+		}
+	}
+}
+
+// WrapContext return a ContextFunction that call function when the circuit allow it and record its outcome,
+// a rejected call return a Return with a *CircuitOpenError without calling function. A panic is recorded as a
+// failure and propagated
+func (_self *CircuitBreaker) WrapContext(function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		generation, err := _self.acquire()
+		if err != nil {
+			return NewReturn(err)
+		}
+		completed := false
+		defer func() {
+			if !completed {
+				_self.record(generation, circuitFailure)
+			}
+		}()
+		ret := function(ctx)
+		completed = true
+		_self.record(generation, _self.outcomeOf(ctx, ret.Error()))
+		return ret
+	}
+}
+
+// Wrap return a Function that call function when the circuit allow it and record its outcome
+func (_self *CircuitBreaker) Wrap(function Function) Function {
+	wrapped := _self.WrapContext(func(ctx context.Context) Return {
+		return function()
+	})
+	return func() Return {
+		return wrapped(context.Background())
+	}
+}
@@ -0,0 +1,198 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// callBreaker call breaker with a function that return the error of each outcome, nil for success
+func callBreaker(breaker *CircuitBreaker, outcomes ...error) {
+	for _, err := range outcomes {
+		err := err
+		breaker.Wrap(func() Return {
+			return NewReturn(err)
+		})()
+	}
+}
+
+func Test_GivenConsecutiveFailures_WhenCall_ThenRejectWithCircuitOpenError(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(3), WithCountWindow(0))
+	callBreaker(breaker, errNormal, errNormal, nil, errNormal, errNormal)
+	assert.Equal(t, CircuitClosed, breaker.State(), "a success must reset consecutive failures")
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+	ret := breaker.Wrap(func() Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})()
+	assert.ErrorIs(t, ret.Error(), ErrCircuitOpen)
+	var openError *CircuitOpenError
+	assert.ErrorAs(t, ret.Error(), &openError)
+	assert.Equal(t, CircuitOpen, openError.State)
+	assert.Greater(t, openError.RetryAfter, time.Duration(0))
+	assert.Contains(t, openError.Error(), "circuit open")
+}
+
+func Test_GivenFailureRate_WhenMinimumCallsReached_ThenOpenCircuit(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(0), WithFailureRate(0.5, 4))
+	callBreaker(breaker, nil, errNormal, nil)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenCountWindow_WhenOldCallsLeaveWindow_ThenUseLastCalls(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(0), WithFailureRate(1, 2), WithCountWindow(2))
+	callBreaker(breaker, errNormal, nil, errNormal)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenTimeWindow_WhenOldCallsExpire_ThenUseRecentCalls(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(0), WithFailureRate(1, 2), WithTimeWindow(20*time.Millisecond))
+	callBreaker(breaker, errNormal)
+	time.Sleep(30 * time.Millisecond)
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenOpenDurationElapsed_WhenProbesSuccess_ThenCloseCircuit(t *testing.T) {
+	var changes []string
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1), WithOpenDuration(20*time.Millisecond), WithHalfOpenProbes(2),
+		WithStateChangeHook(func(from CircuitState, to CircuitState) {
+			changes = append(changes, from.String()+"->"+to.String())
+		}))
+	callBreaker(breaker, errNormal)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	callBreaker(breaker, nil)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	callBreaker(breaker, nil)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+}
+
+func Test_GivenHalfOpenCircuit_WhenProbeFail_ThenOpenCircuitAgain(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1), WithOpenDuration(20*time.Millisecond), WithHalfOpenProbes(0))
+	callBreaker(breaker, errNormal)
+	time.Sleep(30 * time.Millisecond)
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenProbeInFlight_WhenCall_ThenRejectUntilProbeComplete(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1), WithOpenDuration(20*time.Millisecond))
+	callBreaker(breaker, errNormal)
+	time.Sleep(30 * time.Millisecond)
+	started, release := make(chan struct{}), make(chan struct{})
+	var group sync.WaitGroup
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		breaker.Wrap(func() Return {
+			close(started)
+			<-release
+			return NewReturn(nil)
+		})()
+	}()
+	<-started
+	ret := breaker.Wrap(successFunction)()
+	var openError *CircuitOpenError
+	assert.ErrorAs(t, ret.Error(), &openError)
+	assert.Equal(t, CircuitHalfOpen, openError.State)
+	close(release)
+	group.Wait()
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func Test_GivenCallAdmittedBeforeStateChange_WhenComplete_ThenOutcomeIsIgnored(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1))
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		breaker.Wrap(func() Return {
+			close(started)
+			<-release
+			return NewReturn(nil)
+		})()
+	}()
+	<-started
+	callBreaker(breaker, errNormal)
+	close(release)
+	<-done
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenPanicFunction_WhenJoinWrappedFunction_ThenRecordFailureAndReturnPanicError(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1))
+	_, err := JoinFailOnAnyError(breaker.Wrap(panicFunction))
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+	assert.Equal(t, CircuitOpen, breaker.State())
+	_, err = JoinFailOnAnyError(breaker.Wrap(successFunction))
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func Test_GivenCancelledFunction_WhenCall_ThenItIsNotFailureByDefault(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1))
+	callBreaker(breaker, context.Canceled)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	breaker = NewCircuitBreaker(WithConsecutiveFailures(1), WithFailurePredicate(func(err error) bool {
+		return errors.Is(err, context.Canceled)
+	}))
+	callBreaker(breaker, errNormal)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	breaker.WrapContext(func(ctx context.Context) Return {
+		return NewReturn(ctx.Err())
+	})(ctx)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenHalfOpenCircuit_WhenProbeIsCancelled_ThenReleaseProbeWithoutClosing(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(1), WithOpenDuration(10*time.Millisecond))
+	callBreaker(breaker, errNormal)
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrSuccessFound)
+	breaker.WrapContext(func(ctx context.Context) Return {
+		return NewReturn(context.Cause(ctx))
+	})(ctx)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	callBreaker(breaker, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(), "the cancelled probe must release its slot")
+}
+
+func Test_GivenClosedCircuit_WhenCallIsCancelled_ThenDoNotResetConsecutiveFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(2))
+	callBreaker(breaker, errNormal, context.Canceled, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenPredicateTrueForNil_WhenCallSuccess_ThenItIsNotFailure(t *testing.T) {
+	errNotFound := errors.New("not found")
+	breaker := NewCircuitBreaker(WithConsecutiveFailures(2), WithFailurePredicate(func(err error) bool {
+		return !errors.Is(err, errNotFound)
+	}))
+	callBreaker(breaker, nil, nil, errNotFound)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	callBreaker(breaker, errNormal, errNormal)
+	assert.Equal(t, CircuitOpen, breaker.State())
+}
+
+func Test_GivenCircuitState_WhenString_ThenReturnName(t *testing.T) {
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "unknown", CircuitState(-1).String())
+}
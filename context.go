@@ -0,0 +1,46 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSuccessFound is the cancellation cause of the context passed to the functions
+	// when the join is decided because a function success
+	ErrSuccessFound = errors.New("success found")
+)
+
+// ContextFunction function that receive a context, the context is cancelled when the join outcome
+// is decided, the cancellation cause is available using context.Cause
+type ContextFunction func(ctx context.Context) Return
+
+// JoinFailOnAnyErrorContext Run functions and return when any function fail, the context received by
+// functions is cancelled with the error as cause when any function fail
+func JoinFailOnAnyErrorContext(ctx context.Context, funcs ...ContextFunction) ([]Return, error) {
+	return joinFailOnError(ctx, spawnGoroutine, nil, funcs)
+}
+
+// JoinFailOnErrorOrTimeoutContext Run functions and return when complete or fail if a function fail or timeout,
+// the context received by functions is cancelled with the error or ErrTimeout as cause
+func JoinFailOnErrorOrTimeoutContext(ctx context.Context, duration time.Duration, funcs ...ContextFunction) ([]Return, error) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	return joinFailOnError(ctx, spawnGoroutine, timer.C, funcs)
+}
+
+// JoinCompleteOnAnySuccessContext run function and return when any success, if all function return error
+// then return second value equals to false, true otherwise. The context received by functions is
+// cancelled with ErrSuccessFound as cause when any function success
+func JoinCompleteOnAnySuccessContext(ctx context.Context, funcs ...ContextFunction) ([]Return, bool) {
+	return joinCompleteOnAnySuccess(ctx, spawnGoroutine, funcs)
+}
+
+// JoinCompleteAllContext Run functions and return when complete all functions or the context is done, second
+// value return true if all functions success, false otherwise. Slots of functions that do not complete before
+// the context is done have StatusCancelled or StatusTimedOut when the context deadline is exceeded
+func JoinCompleteAllContext(ctx context.Context, funcs ...ContextFunction) ([]Return, bool) {
+	returns := joinCompleteAll(ctx, spawnGoroutine, funcs)
+	return returns, ErrorOf(returns) == nil
+}
@@ -0,0 +1,124 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func successContextFunction(ctx context.Context) Return {
+	return NewReturn(nil, successValue)
+}
+
+func errorContextFunction(ctx context.Context) Return {
+	return NewReturn(errNormal)
+}
+
+func panicContextFunction(ctx context.Context) Return {
+	panic("panic")
+}
+
+// causeContextFunction return a function that close started, wait until the context is done and write
+// the cancellation cause
+func causeContextFunction(started chan struct{}, causes chan error) ContextFunction {
+	return func(ctx context.Context) Return {
+		close(started)
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return NewReturn(ctx.Err())
+	}
+}
+
+// afterStarted return a function that call function once started is closed
+func afterStarted(started chan struct{}, function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		<-started
+		return function(ctx)
+	}
+}
+
+// JoinFailOnAnyErrorContext tests
+
+func Test_GivenSuccessFunctions_WhenJoinFailOnAnyErrorContext_ThenReturnNilError(t *testing.T) {
+	returnValues, err := JoinFailOnAnyErrorContext(context.Background(), successContextFunction, successContextFunction)
+	assert.Nil(t, err, "JoinFailOnAnyErrorContext must return nil error")
+	assert.Equal(t, successValue, returnValues[1].ReturnValues()[0])
+}
+
+func Test_GivenOneFunctionFail_WhenJoinFailOnAnyErrorContext_ThenCancelOthersWithErrorCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	_, err := JoinFailOnAnyErrorContext(context.Background(), causeContextFunction(started, causes), afterStarted(started, errorContextFunction))
+	assert.ErrorIs(t, err, errNormal)
+	assert.ErrorIs(t, <-causes, errNormal, "the context must be cancelled with the error as cause")
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinFailOnAnyErrorContext_ThenReturnError(t *testing.T) {
+	_, err := JoinFailOnAnyErrorContext(context.Background(), panicContextFunction)
+	assert.Error(t, err)
+}
+
+func Test_GivenCancelledParentContext_WhenJoinFailOnAnyErrorContext_ThenReturnParentCause(t *testing.T) {
+	errParent := errors.New("parent")
+	parent, cancel := context.WithCancelCause(context.Background())
+	cancel(errParent)
+	returns, err := JoinFailOnAnyErrorContext(parent, successContextFunction)
+	assert.ErrorIs(t, err, errParent)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+}
+
+func Test_GivenParentCancelledWhileRunning_WhenJoinFailOnAnyErrorContext_ThenReturnParentCause(t *testing.T) {
+	errParent := errors.New("parent")
+	parent, cancel := context.WithCancelCause(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	time.AfterFunc(10*time.Millisecond, func() {
+		cancel(errParent)
+	})
+	returns, err := JoinFailOnAnyErrorContext(parent, func(ctx context.Context) Return {
+		<-release
+		return NewReturn(nil)
+	})
+	assert.ErrorIs(t, err, errParent)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+}
+
+// JoinFailOnErrorOrTimeoutContext tests
+
+func Test_GivenSlowFunction_WhenJoinFailOnErrorOrTimeoutContext_ThenCancelWithTimeoutCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	_, err := JoinFailOnErrorOrTimeoutContext(context.Background(), 50*time.Millisecond, causeContextFunction(started, causes))
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, <-causes, ErrTimeout, "the context must be cancelled with ErrTimeout as cause")
+}
+
+func Test_GivenSuccessFunctions_WhenJoinFailOnErrorOrTimeoutContext_ThenReturnNilError(t *testing.T) {
+	_, err := JoinFailOnErrorOrTimeoutContext(context.Background(), time.Second, successContextFunction)
+	assert.Nil(t, err)
+}
+
+// JoinCompleteOnAnySuccessContext tests
+
+func Test_GivenOneSuccessFunction_WhenJoinCompleteOnAnySuccessContext_ThenCancelOthersWithSuccessFoundCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	returnValues, isSuccess := JoinCompleteOnAnySuccessContext(context.Background(), causeContextFunction(started, causes),
+		errorContextFunction, afterStarted(started, successContextFunction))
+	assert.True(t, isSuccess, "JoinCompleteOnAnySuccessContext must return second value equals to true")
+	assert.Equal(t, successValue, returnValues[2].ReturnValues()[0])
+	assert.ErrorIs(t, <-causes, ErrSuccessFound, "the context must be cancelled with ErrSuccessFound as cause")
+}
+
+func Test_GivenFailFunctions_WhenJoinCompleteOnAnySuccessContext_ThenReturnFalse(t *testing.T) {
+	_, isSuccess := JoinCompleteOnAnySuccessContext(context.Background(), errorContextFunction, panicContextFunction)
+	assert.False(t, isSuccess, "JoinCompleteOnAnySuccessContext must return second value equals to false")
+}
+
+func Test_GivenCancelledParentContext_WhenJoinCompleteOnAnySuccessContext_ThenReturnFalse(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	returns, isSuccess := JoinCompleteOnAnySuccessContext(parent, successContextFunction)
+	assert.False(t, isSuccess)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+}
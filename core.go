@@ -2,9 +2,9 @@
 package gauss
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"sync"
+	"runtime/debug"
 	"time"
 )
 
@@ -17,311 +17,300 @@ type Return interface {
 	ReturnValues() []interface{}
 }
 
+// returnImpl Return implementation on top of a result with return values as value
 type returnImpl struct {
-	error        error
-	returnValues []interface{}
+	result[[]interface{}]
 }
 
 func (_self *returnImpl) Error() error {
-	return _self.error
+	return _self.err
 }
 func (_self *returnImpl) ReturnValues() []interface{} {
-	return _self.returnValues
+	return _self.value
+}
+
+func (_self *returnImpl) withError(err error) Return {
+	return &returnImpl{result[[]interface{}]{value: _self.value, err: err}}
 }
 
 func NewReturn(err error, returnValues ...interface{}) Return {
-	return &returnImpl{error: err, returnValues: returnValues}
+	return &returnImpl{result[[]interface{}]{value: returnValues, err: err}}
 }
 
 type Function func() Return
 type SuccessFunction func(returns []Return)
 type FailFunction func(returns []Return, err error)
 
-func sendErrorToChannelOnPanic(errorChannel chan error) {
-	if r := recover(); r != nil {
-		errorChannel <- fmt.Errorf("%v", r)
+// contextFunction convert function to a ContextFunction, function can not observe the context so it is
+// not called when the context is already done, e.g. it was queued and the join outcome was decided
+func contextFunction(function Function) ContextFunction {
+	return func(ctx context.Context) Return {
+		if ctx.Err() != nil {
+			cause := context.Cause(ctx)
+			return newStatusReturnWithCause(statusOfCause(cause), cause)
+		}
+		return function()
 	}
 }
 
-func writeResultOnPanic(result []Return, index int, wg *sync.WaitGroup) {
-	if r := recover(); r != nil {
-		result[index] = NewReturn(fmt.Errorf("%v", r))
-		wg.Done()
+func contextFunctions(funcs []Function) []ContextFunction {
+	functions := make([]ContextFunction, len(funcs))
+	for index, function := range funcs {
+		functions[index] = contextFunction(function)
 	}
+	return functions
 }
 
-// JoinFailOnAnyError Run functions and return when any function fail
-func JoinFailOnAnyError(funcs ...Function) ([]Return, error) {
-	errorChannel := make(chan error)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	runFunctionFailOnAnyError(returns, errorChannel, &wg, funcs)
-
-	go waitAndCloseChannel(&wg, completeChannel)
-
-	return selectWithCompleteErrorChannel(returns, completeChannel, errorChannel)
+// completion Return of the function with index, when it was started and how long it took
+type completion struct {
+	index    int
+	ret      Return
+	started  time.Time
+	duration time.Duration
 }
 
-// JoinFailOnAnyErrorSuccessFailFunction Run functions and execute successFunction if success or call failFunction if any function fail
-func JoinFailOnAnyErrorSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
-	errorChannel := make(chan error)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	runFunctionFailOnAnyError(returns, errorChannel, &wg, funcs)
+// Join modes collect completions in a returns slice owned by the caller, goroutines never write into it,
+// so the returned slice is a consistent snapshot. Slots of functions that did not complete when the join
+// returned contain a Return with the Status of the function, see StatusOf.
 
-	go waitAndCloseChannel(&wg, completeChannel)
+// submitFunction submit a task to run asynchronously, return an error if the task is rejected
+type submitFunction func(ctx context.Context, task func()) error
 
-	select {
-	case <-completeChannel:
-		successFunction(returns)
-	case err := <-errorChannel:
-		failFunction(returns, err)
-	}
+// spawnGoroutine submitFunction that run every task in its own goroutine
+func spawnGoroutine(_ context.Context, task func()) error {
+	go task()
+	return nil
 }
 
-func runFunctionFailOnAnyError(returns []Return, errorChannel chan error, wg *sync.WaitGroup, funcs []Function) {
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function) {
-			defer sendErrorToChannelOnPanic(errorChannel)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			if returnValuesByFunction.Error() != nil {
-				errorChannel <- returnValuesByFunction.Error()
-			}
-			wg.Done()
-		}(returns, index, function)
-	}
+// startFunctions submit every function from its own goroutine, so the join observe completions, its timeout
+// and its context while a submit block. The channel is buffered with capacity for all functions, so tasks
+// never block when the join already returned
+func startFunctions(ctx context.Context, submit submitFunction, funcs []ContextFunction) chan completion {
+	completions := make(chan completion, len(funcs))
+	go func() {
+		for index, function := range funcs {
+			submitTask(ctx, submit, index, function, completions)
+		}
+	}()
+	return completions
 }
 
-func selectWithCompleteErrorChannel(returns []Return, completeChannel chan bool, errorChannel chan error) ([]Return, error) {
-	select {
-	case <-completeChannel:
-		return returns, nil
-	case err := <-errorChannel:
-		return returns, err
+// submitTask submit a task that run function and send its completion, a rejected task send a completion
+// with the error of submit. Joins call it outside their select loop because submit can block, e.g. on a
+// rate limiter or a full Executor queue. Once ctx is done function is not submitted, the completion contains
+// the status of the context
+func submitTask(ctx context.Context, submit submitFunction, index int, function ContextFunction, completions chan<- completion) {
+	if ctx.Err() != nil {
+		completions <- completion{index: index, ret: doneReturns(1, context.Cause(ctx))[0], started: time.Now()}
+		return
+	}
+	err := submit(ctx, func() {
+		started := time.Now()
+		ret := runFunction(ctx, index, function)
+		completions <- completion{index: index, ret: ret, started: started, duration: time.Since(started)}
+	})
+	if err != nil {
+		completions <- completion{index: index, ret: NewReturn(err), started: time.Now()}
 	}
 }
 
-// JoinCompleteAll Run functions and return when complete all functions, first return value contain
-// return values and second value return true if success operation, false otherwise.
-func JoinCompleteAll(funcs ...Function) ([]Return, bool) {
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function, waitGroup *sync.WaitGroup) {
-			defer writeResultOnPanic(returnValues, index, waitGroup)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			wg.Done()
-		}(returns, index, function, &wg)
-	}
-	wg.Wait()
-	isSuccess := true
-	for _, result := range returns {
-		if result.Error() != nil {
-			isSuccess = false
-			break
+// runFunction call function and return its Return, if the function panic return a Return with a *PanicError.
+// Every join mode run functions with runFunction, so a panic always produce a completion
+func runFunction(ctx context.Context, index int, function ContextFunction) (ret Return) {
+	defer func() {
+		if r := recover(); r != nil {
+			ret = NewReturn(&PanicError{Value: r, Index: index, Stack: debug.Stack()})
 		}
+	}()
+	ret = function(ctx)
+	if err := indexedError(ret.Error(), index); err != ret.Error() {
+		ret = withError(ret, err)
 	}
-	return returns, isSuccess
+	return ret
 }
 
-// JoinCompleteAllSuccessFailFunction Run functions and call complete functions if success or
-// call failFunction if any fail
-func JoinCompleteAllSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function, waitGroup *sync.WaitGroup) {
-			defer writeResultOnPanic(returnValues, index, waitGroup)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			wg.Done()
-		}(returns, index, function, &wg)
+// indexedError return a copy with index of a *PanicError or *TimeoutError created by a wrapper that does not
+// know the index of the function in the join, these errors have a negative index. The error is copied
+// because the Return of a Future, a Group or a Cache is shared by every caller
+func indexedError(err error, index int) error {
+	if index < 0 {
+		return err
 	}
-	wg.Wait()
-	isSuccess := true
-	var err error = nil
-	for _, result := range returns {
-		if result.Error() != nil {
-			isSuccess = false
-			err = result.Error()
-			break
+	switch typed := err.(type) {
+	case *PanicError:
+		if typed.Index < 0 {
+			indexed := *typed
+			indexed.Index = index
+			return &indexed
+		}
+	case *TimeoutError:
+		if typed.Index < 0 {
+			indexed := *typed
+			indexed.Index = index
+			return &indexed
 		}
 	}
+	return err
+}
 
-	if isSuccess {
-		successFunction(returns)
-	} else {
-		failFunction(returns, err)
-	}
+// errorReplacer Return that can be copied with another error, keeping its type
+type errorReplacer interface {
+	withError(err error) Return
 }
 
-// JoinCompleteOnAnySuccess run function and return when any success, if all function return error
-// then return second value equals to false, true otherwise
-func JoinCompleteOnAnySuccess(funcs ...Function) ([]Return, bool) {
-	finishChannel := make(chan bool)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function, waitGroup *sync.WaitGroup) {
-			defer writeResultOnPanic(returnValues, index, waitGroup)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			if returnValuesByFunction.Error() == nil {
-				finishChannel <- true
-			}
-			wg.Done()
-		}(returns, index, function, &wg)
+// withError return a copy of ret with err
+func withError(ret Return, err error) Return {
+	if replacer, ok := ret.(errorReplacer); ok {
+		return replacer.withError(err)
 	}
-
-	go waitAndCloseChannel(&wg, completeChannel)
-
-	return selectWithCompleteFinishChannel(returns, completeChannel, finishChannel)
+	return NewReturn(err, ret.ReturnValues()...)
 }
 
-func JoinCompleteOnAnySuccessSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
-	finishChannel := make(chan bool)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function, waitGroup *sync.WaitGroup) {
-			defer writeResultOnPanic(returnValues, index, waitGroup)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			if returnValuesByFunction.Error() == nil {
-				finishChannel <- true
-			}
-			wg.Done()
-		}(returns, index, function, &wg)
+// doneReturns Returns of a join that does not call its functions because the context is already done
+func doneReturns(size int, cause error) []Return {
+	returns := make([]Return, size)
+	for index := range returns {
+		returns[index] = newStatusReturnWithCause(statusOfCause(cause), cause)
 	}
-
-	go waitAndCloseChannel(&wg, completeChannel)
-
-	selectWithCompleteFinishChannelCallSuccessFailFunction(successFunction, failFunction, returns, completeChannel, finishChannel)
+	return returns
 }
 
-func selectWithCompleteFinishChannelCallSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, returns []Return, completeChannel chan bool, finishChannel chan bool) {
-	select {
-	case <-completeChannel:
-		if existSuccessResult(returns) {
-			successFunction(returns)
-		} else {
-			failFunction(returns, getFirstError(returns))
+// joinFailOnError wait until all functions complete, any function fail, timeout or parent context done,
+// the context received by functions is cancelled with the error as cause
+func joinFailOnError(parent context.Context, submit submitFunction, timeout <-chan time.Time, funcs []ContextFunction) ([]Return, error) {
+	if parent.Err() != nil {
+		cause := context.Cause(parent)
+		return doneReturns(len(funcs), cause), cause
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	returns := make([]Return, len(funcs))
+	completions := startFunctions(ctx, submit, funcs)
+	var err error
+	status := StatusPending
+	for pending := len(funcs); pending > 0 && err == nil; pending-- {
+		select {
+		case c := <-completions:
+			returns[c.index] = c.ret
+			err = c.ret.Error()
+		case <-timeout:
+			err = ErrTimeout
+			status = StatusTimedOut
+		case <-ctx.Done():
+			err = context.Cause(ctx)
+			status = statusOfCause(err)
 		}
-	case <-finishChannel:
-		successFunction(returns)
 	}
+	cancel(err)
+	return fillIncomplete(returns, status), err
 }
 
-func selectWithCompleteFinishChannel(returns []Return, completeChannel chan bool, finishChannel chan bool) ([]Return, bool) {
-	select {
-	case <-completeChannel:
-		return returns, existSuccessResult(returns)
-	case <-finishChannel:
-		return returns, true
+// joinCompleteOnAnySuccess wait until any function success, all functions fail or parent context done,
+// the context received by functions is cancelled with ErrSuccessFound as cause when any function success
+func joinCompleteOnAnySuccess(parent context.Context, submit submitFunction, funcs []ContextFunction) ([]Return, bool) {
+	if parent.Err() != nil {
+		return doneReturns(len(funcs), context.Cause(parent)), false
 	}
-}
-
-func existSuccessResult(returns []Return) bool {
-	isSuccess := false
-	for _, r := range returns {
-		if r.Error() == nil {
-			isSuccess = true
-			break
+	ctx, cancel := context.WithCancelCause(parent)
+	returns := make([]Return, len(funcs))
+	completions := startFunctions(ctx, submit, funcs)
+	for pending := len(funcs); pending > 0; pending-- {
+		select {
+		case c := <-completions:
+			returns[c.index] = c.ret
+			if c.ret.Error() == nil {
+				cancel(ErrSuccessFound)
+				return fillIncomplete(returns, StatusPending), true
+			}
+		case <-ctx.Done():
+			cancel(nil)
+			return fillIncomplete(returns, statusOfCause(context.Cause(ctx))), false
 		}
 	}
-	return isSuccess
+	cancel(nil)
+	return returns, false
 }
 
-func getFirstError(returns []Return) error {
-	for _, r := range returns {
-		if r.Error() != nil {
-			return r.Error()
+// joinCompleteAll wait until all functions complete or parent context done, the context received by
+// functions is cancelled with the parent cause
+func joinCompleteAll(parent context.Context, submit submitFunction, funcs []ContextFunction) []Return {
+	if parent.Err() != nil {
+		return doneReturns(len(funcs), context.Cause(parent))
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	returns := make([]Return, len(funcs))
+	completions := startFunctions(ctx, submit, funcs)
+	for range funcs {
+		select {
+		case c := <-completions:
+			returns[c.index] = c.ret
+		case <-ctx.Done():
+			return fillIncomplete(returns, statusOfCause(context.Cause(ctx)))
 		}
 	}
-	return nil
+	return returns
 }
 
-// JoinFailOnErrorOrTimeout Run functions and return when complete or fail if a function fail or timeout
-func JoinFailOnErrorOrTimeout(duration time.Duration, funcs ...Function) ([]Return, error) {
-	errorChannel := make(chan error)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function) {
-			defer sendErrorToChannelOnPanic(errorChannel)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			if returnValuesByFunction.Error() != nil {
-				errorChannel <- returnValuesByFunction.Error()
-			}
-			wg.Done()
-		}(returns, index, function)
+func callSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, returns []Return, err error) {
+	if err == nil {
+		successFunction(returns)
+	} else {
+		failFunction(returns, err)
 	}
+}
 
-	go waitAndCloseChannel(&wg, completeChannel)
+// JoinFailOnAnyError Run functions and return when any function fail
+func JoinFailOnAnyError(funcs ...Function) ([]Return, error) {
+	return joinFailOnError(context.Background(), spawnGoroutine, nil, contextFunctions(funcs))
+}
 
-	return selectWithCompleteErrorChannelAndTimer(returns, completeChannel, errorChannel, duration)
+// JoinFailOnAnyErrorSuccessFailFunction Run functions and execute successFunction if success or call failFunction if any function fail
+func JoinFailOnAnyErrorSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns, err := JoinFailOnAnyError(funcs...)
+	callSuccessFailFunction(successFunction, failFunction, returns, err)
 }
 
-func JoinFailOnErrorOrTimeoutSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, duration time.Duration, funcs ...Function) {
-	errorChannel := make(chan error)
-	completeChannel := make(chan bool)
-	var wg sync.WaitGroup
-	returns := make([]Return, len(funcs))
-	for index, function := range funcs {
-		wg.Add(1)
-		go func(returnValues []Return, index int, function Function) {
-			defer sendErrorToChannelOnPanic(errorChannel)
-			returnValuesByFunction := function()
-			returnValues[index] = returnValuesByFunction
-			if returnValuesByFunction.Error() != nil {
-				errorChannel <- returnValuesByFunction.Error()
-			}
-			wg.Done()
-		}(returns, index, function)
-	}
+// JoinCompleteAll Run functions and return when complete all functions, first return value contain
+// return values and second value return true if success operation, false otherwise.
+func JoinCompleteAll(funcs ...Function) ([]Return, bool) {
+	returns := joinCompleteAll(context.Background(), spawnGoroutine, contextFunctions(funcs))
+	return returns, ErrorOf(returns) == nil
+}
 
-	go waitAndCloseChannel(&wg, completeChannel)
+// JoinCompleteAllSuccessFailFunction Run functions and call complete functions if success or
+// call failFunction if any fail
+func JoinCompleteAllSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns := joinCompleteAll(context.Background(), spawnGoroutine, contextFunctions(funcs))
+	callSuccessFailFunction(successFunction, failFunction, returns, ErrorOf(returns))
+}
 
-	selectWithCompleteErrorChannelAndTimerSuccessFailFunction(successFunction, failFunction, returns, completeChannel, errorChannel, duration)
+// JoinCompleteOnAnySuccess run function and return when any success, if all function return error
+// then return second value equals to false, true otherwise
+func JoinCompleteOnAnySuccess(funcs ...Function) ([]Return, bool) {
+	return joinCompleteOnAnySuccess(context.Background(), spawnGoroutine, contextFunctions(funcs))
+}
+
+func JoinCompleteOnAnySuccessSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns, isSuccess := JoinCompleteOnAnySuccess(funcs...)
+	callAnySuccessFailFunction(successFunction, failFunction, returns, isSuccess)
 }
 
-func selectWithCompleteErrorChannelAndTimerSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, returns []Return, completeChannel chan bool, errorChannel chan error, duration time.Duration) {
-	select {
-	case <-completeChannel:
+func callAnySuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, returns []Return, isSuccess bool) {
+	if isSuccess {
 		successFunction(returns)
-	case err := <-errorChannel:
-		failFunction(returns, err)
-	case <-time.After(duration):
-		failFunction(returns, ErrTimeout)
+	} else {
+		failFunction(returns, ErrorOf(returns))
 	}
 }
 
-func selectWithCompleteErrorChannelAndTimer(returns []Return, completeChannel chan bool, errorChannel chan error, duration time.Duration) ([]Return, error) {
-	select {
-	case <-completeChannel:
-		return returns, nil
-	case err := <-errorChannel:
-		return returns, err
-	case <-time.After(duration):
-		return returns, ErrTimeout
-	}
+// JoinFailOnErrorOrTimeout Run functions and return when complete or fail if a function fail or timeout
+func JoinFailOnErrorOrTimeout(duration time.Duration, funcs ...Function) ([]Return, error) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	return joinFailOnError(context.Background(), spawnGoroutine, timer.C, contextFunctions(funcs))
 }
 
-func waitAndCloseChannel(wg *sync.WaitGroup, completeChannel chan bool) {
-	wg.Wait()
-	close(completeChannel)
+func JoinFailOnErrorOrTimeoutSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, duration time.Duration, funcs ...Function) {
+	returns, err := JoinFailOnErrorOrTimeout(duration, funcs...)
+	callSuccessFailFunction(successFunction, failFunction, returns, err)
 }
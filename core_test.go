@@ -205,57 +205,44 @@ func Test_GivenFunctionDoPanic_WhenJoinCompleteOnAnySuccessSuccessFailFunction_T
 	}, panicFunction)
 }
 
-// getFirstError tests
+// ErrorOf tests
 
-func Test_GivenArrayReturnWithoutErrors_WhenGetFirstError_ThenReturnNil(t *testing.T) {
+func Test_GivenArrayReturnWithoutErrors_WhenErrorOf_ThenReturnNil(t *testing.T) {
 	returns := []Return{NewReturn(nil)}
-	err := getFirstError(returns)
-	assert.Nil(t, err, "getFirstError must return a nil error")
+	err := ErrorOf(returns)
+	assert.Nil(t, err, "ErrorOf must return a nil error")
 }
 
-func Test_GivenArrayReturnWithErrors_WhenGetFirstError_ThenReturnError(t *testing.T) {
+func Test_GivenArrayReturnWithErrors_WhenErrorOf_ThenReturnError(t *testing.T) {
 	returns := []Return{NewReturn(errors.New("error"))}
-	err := getFirstError(returns)
-	assert.Error(t, err, "getFirstError must return an error")
+	err := ErrorOf(returns)
+	assert.Error(t, err, "ErrorOf must return an error")
 }
 
-// selectWithCompleteFinishChannelCallSuccessFailFunction tests
+// callSuccessFailFunction tests
 
-func Test_GivenCloseCompleteChannelAndNoErrors_WhenSelectWithCompleteFinishChannelCallSuccessFailFunction_ThenCallSucessFunction(t *testing.T) {
+func Test_GivenNilError_WhenCallSuccessFailFunction_ThenCallSucessFunction(t *testing.T) {
 	// Given
 	returns := []Return{NewReturn(nil)}
-	completeChannel := make(chan bool)
-	close(completeChannel)
-	finishChannel := make(chan bool)
 	// When
-	selectWithCompleteFinishChannelCallSuccessFailFunction(func(returns []Return) {
+	callSuccessFailFunction(func(returns []Return) {
 		// Then
-		assert.True(t, true, "selectWithCompleteFinishChannelCallSuccessFailFunction must call success function")
+		assert.True(t, true, "callSuccessFailFunction must call success function")
 	}, func(returns []Return, err error) {
-		assert.True(t, false, "selectWithCompleteFinishChannelCallSuccessFailFunction must no call fail function")
-	}, returns, completeChannel, finishChannel)
+		assert.True(t, false, "callSuccessFailFunction must no call fail function")
+	}, returns, nil)
 }
 
-func Test_GivenCloseCompleteChannelAndErrors_WhenSelectWithCompleteFinishChannelCallSuccessFailFunction_ThenCallFailFunction(t *testing.T) {
+func Test_GivenError_WhenCallSuccessFailFunction_ThenCallFailFunction(t *testing.T) {
 	// Given
-	returns := []Return{NewReturn(errors.New("error"))}
-	completeChannel := make(chan bool)
-	close(completeChannel)
-	finishChannel := make(chan bool)
+	returns := []Return{NewReturn(errNormal)}
 	// When
-	selectWithCompleteFinishChannelCallSuccessFailFunction(func(returns []Return) {
-		assert.True(t, false, "selectWithCompleteFinishChannelCallSuccessFailFunction must no call success function")
+	callSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "callSuccessFailFunction must no call success function")
 	}, func(returns []Return, err error) {
 		// Then
-		assert.True(t, true, "selectWithCompleteFinishChannelCallSuccessFailFunction must call fail function")
-	}, returns, completeChannel, finishChannel)
-}
-
-// existSuccessResult tests
-
-func TestOneReturnFailAndOneSuccess_WhenExistSuccessResult_ThenReturnTrue(t *testing.T) {
-	resturns := []Return{NewReturn(nil), NewReturn(errors.New("Error"))}
-	assert.True(t, existSuccessResult(resturns))
+		assert.ErrorIs(t, err, errNormal, "callSuccessFailFunction must call fail function with the error")
+	}, returns, errNormal)
 }
 
 // JoinFailOnErrorOrTimeout tests
@@ -301,3 +288,26 @@ func Test_GivenErrorFuction_WhenJoinFailOnErrorOrTimeoutSuccessFailFunctionWithT
 		assert.True(t, true, "JoinFailOnErrorOrTimeoutSuccessFailFunction must call fail function")
 	}, 100*time.Millisecond, errorFunction)
 }
+
+// foreignReturn Return implemented outside the package
+type foreignReturn struct {
+	err error
+}
+
+func (_self *foreignReturn) Error() error {
+	return _self.err
+}
+
+func (_self *foreignReturn) ReturnValues() []interface{} {
+	return []interface{}{successValue}
+}
+
+func Test_GivenForeignReturnWithPanicError_WhenJoinCompleteAll_ThenPanicErrorHasSlotIndex(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, func() Return {
+		return &foreignReturn{err: &PanicError{Value: "panic", Index: -1}}
+	})
+	var panicError *PanicError
+	assert.ErrorAs(t, returns[1].Error(), &panicError)
+	assert.Equal(t, 1, panicError.Index)
+	assert.Equal(t, successValue, returns[1].ReturnValues()[0])
+}
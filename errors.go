@@ -0,0 +1,176 @@
+package gauss
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PanicError error returned in the Return of a function that panic
+type PanicError struct {
+	// Value recovered value
+	Value interface{}
+	// Index of the function in the join, -1 if the function was not called by a join
+	Index int
+	// Stack trace of the goroutine when the function panic
+	Stack []byte
+}
+
+func (_self *PanicError) Error() string {
+	return fmt.Sprintf("function %d panic: %v", _self.Index, _self.Value)
+}
+
+// Unwrap return the recovered value if it is an error, nil otherwise
+func (_self *PanicError) Unwrap() error {
+	if err, ok := _self.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// TimeoutError error returned in the Return of a function wrapped with WithTimeout that does not complete
+// before its timeout, errors.Is(err, ErrTimeout) is true
+type TimeoutError struct {
+	// Index of the function in the join, -1 if the function was not called by a join
+	Index int
+	// Timeout of the function
+	Timeout time.Duration
+}
+
+func (_self *TimeoutError) Error() string {
+	return fmt.Sprintf("function %d: timeout after %v", _self.Index, _self.Timeout)
+}
+
+func (_self *TimeoutError) Unwrap() error {
+	return ErrTimeout
+}
+
+// FunctionError error returned by a function of a join
+type FunctionError struct {
+	// Index of the function in the join
+	Index int
+	// Name of the function, empty if the function is not wrapped with Named
+	Name string
+	// Err error returned by the function
+	Err error
+}
+
+// Error return the message of Err with the index and name of the function, a *PanicError or *TimeoutError
+// message already contains the index so it is not repeated
+func (_self *FunctionError) Error() string {
+	function := fmt.Sprintf("function %d", _self.Index)
+	if _self.Name != "" {
+		function = fmt.Sprintf("function %d (%s)", _self.Index, _self.Name)
+	}
+	switch err := _self.Err.(type) {
+	case *PanicError:
+		if err.Index == _self.Index {
+			return fmt.Sprintf("%s panic: %v", function, err.Value)
+		}
+	case *TimeoutError:
+		if err.Index == _self.Index {
+			return fmt.Sprintf("%s: timeout after %v", function, err.Timeout)
+		}
+	}
+	return fmt.Sprintf("%s: %v", function, _self.Err)
+}
+
+func (_self *FunctionError) Unwrap() error {
+	return _self.Err
+}
+
+// MultiError errors of every function that fail in a join, errors.Is and errors.As check each error
+// like an error created with errors.Join
+type MultiError struct {
+	Errors []*FunctionError
+}
+
+func (_self *MultiError) Error() string {
+	messages := make([]string, len(_self.Errors))
+	for index, err := range _self.Errors {
+		messages[index] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (_self *MultiError) Unwrap() []error {
+	errs := make([]error, len(_self.Errors))
+	for index, err := range _self.Errors {
+		errs[index] = err
+	}
+	return errs
+}
+
+// ErrorOf return a *MultiError with the error of each Return with error, nil if no Return has error
+func ErrorOf(returns []Return) error {
+	var errs []*FunctionError
+	for index, ret := range returns {
+		if err := ret.Error(); err != nil {
+			errs = append(errs, &FunctionError{Index: index, Name: nameOf(ret), Err: err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: errs}
+}
+
+// namedReturn Return of a function wrapped with Named
+type namedReturn struct {
+	Return
+	name string
+}
+
+func (_self *namedReturn) Name() string {
+	return _self.name
+}
+
+func (_self *namedReturn) withError(err error) Return {
+	return named(_self.name, withError(_self.Return, err))
+}
+
+// namedRetryReturn Return of a function wrapped with Named that return a RetryReturn, so the attempts are
+// still readable from the join slot
+type namedRetryReturn struct {
+	RetryReturn
+	name string
+}
+
+func (_self *namedRetryReturn) Name() string {
+	return _self.name
+}
+
+func (_self *namedRetryReturn) withError(err error) Return {
+	return named(_self.name, withError(_self.RetryReturn, err))
+}
+
+// named return ret with name, keeping the RetryReturn interface of ret
+func named(name string, ret Return) Return {
+	if retryReturn, ok := ret.(RetryReturn); ok {
+		return &namedRetryReturn{RetryReturn: retryReturn, name: name}
+	}
+	return &namedReturn{Return: ret, name: name}
+}
+
+func nameOf(ret Return) string {
+	if named, ok := ret.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
+
+// Named return a Function with the Return of function with name, the name is reported in FunctionError. A
+// RetryReturn keeps its interface, so Named and WithRetry can be combined in any order
+func Named(name string, function Function) Function {
+	return func() Return {
+		return named(name, function())
+	}
+}
+
+// NamedContext return a ContextFunction with the Return of function with name, the name is reported in FunctionError
+func NamedContext(name string, function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		return named(name, function(ctx))
+	}
+}
@@ -0,0 +1,120 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func panicErrorFunction() Return {
+	panic(errNormal)
+}
+
+// PanicError tests
+
+func Test_GivenPanicError_WhenError_ThenReturnIndexAndValue(t *testing.T) {
+	err := &PanicError{Value: "panic", Index: 1}
+	assert.EqualError(t, err, "function 1 panic: panic")
+	assert.Nil(t, errors.Unwrap(err))
+}
+
+func Test_GivenFunctionPanicWithError_WhenJoinCompleteAll_ThenErrorIsRecoveredError(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, panicErrorFunction)
+	assert.ErrorIs(t, returns[1].Error(), errNormal)
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinFailOnAnyError_ThenReturnPanicError(t *testing.T) {
+	_, err := JoinFailOnAnyError(successFunction, panicFunction)
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+	assert.Equal(t, 1, panicError.Index)
+	assert.Equal(t, "panic", panicError.Value)
+	assert.Contains(t, string(panicError.Stack), "panicFunction")
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinCompleteOnAnySuccess_ThenReturnPanicErrorInSlot(t *testing.T) {
+	returns, isSuccess := JoinCompleteOnAnySuccess(errorFunction, panicFunction)
+	assert.False(t, isSuccess)
+	var panicError *PanicError
+	assert.ErrorAs(t, returns[1].Error(), &panicError)
+	assert.Equal(t, 1, panicError.Index)
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinFailOnAnyErrorContext_ThenReturnPanicError(t *testing.T) {
+	_, err := JoinFailOnAnyErrorContext(context.Background(), panicContextFunction)
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+}
+
+func Test_GivenFunctionPanicAfterTimeout_WhenJoinFailOnErrorOrTimeout_ThenPanicFunctionCompletes(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		_, err := JoinFailOnErrorOrTimeout(10*time.Millisecond, panicFunctionAfter50Ms, errorFunctionAfter50Ms)
+		assert.ErrorIs(t, err, ErrTimeout)
+	})
+}
+
+func Test_GivenFunctionPanicAfterError_WhenJoinFailOnAnyError_ThenPanicFunctionCompletes(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		_, err := JoinFailOnAnyError(errorFunction, panicFunctionAfter50Ms)
+		assert.ErrorIs(t, err, errNormal)
+	})
+}
+
+// MultiError tests
+
+func Test_GivenThreeFailingFunctions_WhenJoinCompleteAllSuccessFailFunction_ThenFailFunctionReceiveMultiError(t *testing.T) {
+	errOther := errors.New("other")
+	JoinCompleteAllSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "JoinCompleteAllSuccessFailFunction must no call success function")
+	}, func(returns []Return, err error) {
+		var multiError *MultiError
+		assert.ErrorAs(t, err, &multiError)
+		assert.Len(t, multiError.Errors, 3)
+		assert.Equal(t, 1, multiError.Errors[0].Index)
+		assert.Equal(t, "db", multiError.Errors[0].Name)
+		assert.Equal(t, 3, multiError.Errors[2].Index)
+		assert.ErrorIs(t, err, errNormal)
+		assert.ErrorIs(t, err, errOther)
+		var panicError *PanicError
+		assert.ErrorAs(t, err, &panicError)
+	}, successFunction, Named("db", errorFunction), func() Return {
+		return NewReturn(errOther)
+	}, panicFunction)
+}
+
+func Test_GivenAllFunctionsFail_WhenJoinCompleteOnAnySuccessSuccessFailFunction_ThenFailFunctionReceiveMultiError(t *testing.T) {
+	JoinCompleteOnAnySuccessSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "JoinCompleteOnAnySuccessSuccessFailFunction must no call success function")
+	}, func(returns []Return, err error) {
+		var multiError *MultiError
+		assert.ErrorAs(t, err, &multiError)
+		assert.Len(t, multiError.Errors, 2)
+	}, errorFunction, errorFunctionAfter50Ms)
+}
+
+func Test_GivenJoinCompleteAllReturns_WhenErrorOf_ThenReturnMultiErrorCompatibleWithErrorsJoin(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, errorFunction, Named("cache", errorFunction))
+	err := ErrorOf(returns)
+	assert.EqualError(t, err, "function 1: err-normal\nfunction 2 (cache): err-normal")
+	assert.ErrorIs(t, errors.Join(err), errNormal)
+	var functionError *FunctionError
+	assert.ErrorAs(t, err, &functionError)
+	assert.Equal(t, 1, functionError.Index)
+}
+
+func Test_GivenNamedFunctionsWithTimeout_WhenErrorOf_ThenKeepNameAndPrintIndexOnce(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, Named("db", WithTimeout(successFunctionAfter50Ms, 5*time.Millisecond)),
+		Named("cache", WithTimeout(panicFunction, time.Second)), panicFunction)
+	err := ErrorOf(returns)
+	assert.EqualError(t, err, "function 1 (db): timeout after 5ms\nfunction 2 (cache) panic: panic\nfunction 3 panic: panic")
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[1]))
+	assert.EqualError(t, &FunctionError{Index: 0, Err: &PanicError{Value: "panic", Index: -1}}, "function 0: function -1 panic: panic")
+}
+
+func Test_GivenNamedContextFunction_WhenJoinFailOnAnyErrorContext_ThenReturnKeepName(t *testing.T) {
+	returns, _ := JoinFailOnAnyErrorContext(context.Background(), NamedContext("db", errorContextFunction))
+	assert.Equal(t, "db", ErrorOf(returns).(*MultiError).Errors[0].Name)
+}
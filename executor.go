@@ -0,0 +1,223 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrQueueFull error of the Return of a function rejected by an Executor with QueueFullReject policy
+	ErrQueueFull = errors.New("executor queue full")
+)
+
+// QueueFullPolicy behaviour of an Executor when all workers are busy and the queue is full
+type QueueFullPolicy int
+
+const (
+	// QueueFullBlock block the submission until the queue has space or the join context is done
+	QueueFullBlock QueueFullPolicy = iota
+	// QueueFullReject reject the function, its Return contains ErrQueueFull
+	QueueFullReject
+	// QueueFullCallerRuns run the function in the goroutine submitting the functions of the join
+	QueueFullCallerRuns
+)
+
+// Executor run join functions with a bounded number of goroutines. Workers are started on demand
+// and exit when the queue is empty, an idle Executor has no goroutines
+type Executor struct {
+	workers         chan struct{}
+	queue           chan func()
+	queueFullPolicy QueueFullPolicy
+	queueSize       int
+	limiter         Limiter
+}
+
+type ExecutorOption func(executor *Executor)
+
+// WithQueueSize set the number of functions waiting for a worker, default 0
+func WithQueueSize(queueSize int) ExecutorOption {
+	return func(executor *Executor) {
+		executor.queueSize = queueSize
+	}
+}
+
+// WithQueueFullPolicy set the behaviour when the queue is full, default QueueFullBlock
+func WithQueueFullPolicy(policy QueueFullPolicy) ExecutorOption {
+	return func(executor *Executor) {
+		executor.queueFullPolicy = policy
+	}
+}
+
+// WithRateLimiter wait limiter before start each function, a function whose wait fail is not started and
+// its Return contains the error of Wait
+func WithRateLimiter(limiter Limiter) ExecutorOption {
+	return func(executor *Executor) {
+		executor.limiter = limiter
+	}
+}
+
+// NewExecutor create an Executor that run at most maxParallelism functions at the same time,
+// maxParallelism less than 1 is handled as 1
+func NewExecutor(maxParallelism int, options ...ExecutorOption) *Executor {
+	if maxParallelism < 1 {
+		maxParallelism = 1
+	}
+	executor := &Executor{workers: make(chan struct{}, maxParallelism)}
+	for _, option := range options {
+		option(executor)
+	}
+	if executor.queueSize < 0 {
+		executor.queueSize = 0
+	}
+	executor.queue = make(chan func(), executor.queueSize)
+	return executor
+}
+
+// submit wait the rate limiter, then run task in a worker, queue it or apply the queue full policy. Return
+// the context cause when ctx is done before the task is accepted
+func (_self *Executor) submit(ctx context.Context, task func()) error {
+	if _self.limiter != nil {
+		if err := _self.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	select {
+	case _self.workers <- struct{}{}:
+		go _self.work(task)
+		return nil
+	default:
+	}
+	select {
+	case _self.queue <- task:
+		_self.startWorker()
+		return nil
+	default:
+	}
+	switch _self.queueFullPolicy {
+	case QueueFullReject:
+		return ErrQueueFull
+	case QueueFullCallerRuns:
+		task()
+		return nil
+	}
+	select {
+	case _self.workers <- struct{}{}:
+		go _self.work(task)
+	case _self.queue <- task:
+		_self.startWorker()
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+	return nil
+}
+
+// startWorker start a worker if there is a free slot, the worker take its task from the queue
+func (_self *Executor) startWorker() {
+	select {
+	case _self.workers <- struct{}{}:
+		go _self.work(nil)
+	default:
+	}
+}
+
+func (_self *Executor) work(task func()) {
+	if task == nil {
+		task = _self.next()
+	}
+	for task != nil {
+		task()
+		task = _self.next()
+	}
+}
+
+// next return the next queued task, if the queue is empty release the worker slot and return nil
+func (_self *Executor) next() func() {
+	for {
+		select {
+		case task := <-_self.queue:
+			return task
+		default:
+		}
+		<-_self.workers
+		// a task queued after the empty check and before the release has no worker to run it
+		if len(_self.queue) == 0 {
+			return nil
+		}
+		select {
+		case _self.workers <- struct{}{}:
+		default:
+			return nil
+		}
+	}
+}
+
+// JoinFailOnAnyError JoinFailOnAnyError running functions with the executor
+func (_self *Executor) JoinFailOnAnyError(funcs ...Function) ([]Return, error) {
+	return joinFailOnError(context.Background(), _self.submit, nil, contextFunctions(funcs))
+}
+
+// JoinFailOnAnyErrorSuccessFailFunction JoinFailOnAnyErrorSuccessFailFunction running functions with the executor
+func (_self *Executor) JoinFailOnAnyErrorSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns, err := _self.JoinFailOnAnyError(funcs...)
+	callSuccessFailFunction(successFunction, failFunction, returns, err)
+}
+
+// JoinCompleteAll JoinCompleteAll running functions with the executor
+func (_self *Executor) JoinCompleteAll(funcs ...Function) ([]Return, bool) {
+	returns := joinCompleteAll(context.Background(), _self.submit, contextFunctions(funcs))
+	return returns, ErrorOf(returns) == nil
+}
+
+// JoinCompleteAllSuccessFailFunction JoinCompleteAllSuccessFailFunction running functions with the executor
+func (_self *Executor) JoinCompleteAllSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns := joinCompleteAll(context.Background(), _self.submit, contextFunctions(funcs))
+	callSuccessFailFunction(successFunction, failFunction, returns, ErrorOf(returns))
+}
+
+// JoinCompleteOnAnySuccess JoinCompleteOnAnySuccess running functions with the executor
+func (_self *Executor) JoinCompleteOnAnySuccess(funcs ...Function) ([]Return, bool) {
+	return joinCompleteOnAnySuccess(context.Background(), _self.submit, contextFunctions(funcs))
+}
+
+// JoinCompleteOnAnySuccessSuccessFailFunction JoinCompleteOnAnySuccessSuccessFailFunction running functions with the executor
+func (_self *Executor) JoinCompleteOnAnySuccessSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, funcs ...Function) {
+	returns, isSuccess := _self.JoinCompleteOnAnySuccess(funcs...)
+	callAnySuccessFailFunction(successFunction, failFunction, returns, isSuccess)
+}
+
+// JoinFailOnErrorOrTimeout JoinFailOnErrorOrTimeout running functions with the executor
+func (_self *Executor) JoinFailOnErrorOrTimeout(duration time.Duration, funcs ...Function) ([]Return, error) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	return joinFailOnError(context.Background(), _self.submit, timer.C, contextFunctions(funcs))
+}
+
+// JoinFailOnErrorOrTimeoutSuccessFailFunction JoinFailOnErrorOrTimeoutSuccessFailFunction running functions with the executor
+func (_self *Executor) JoinFailOnErrorOrTimeoutSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, duration time.Duration, funcs ...Function) {
+	returns, err := _self.JoinFailOnErrorOrTimeout(duration, funcs...)
+	callSuccessFailFunction(successFunction, failFunction, returns, err)
+}
+
+// JoinFailOnAnyErrorContext JoinFailOnAnyErrorContext running functions with the executor
+func (_self *Executor) JoinFailOnAnyErrorContext(ctx context.Context, funcs ...ContextFunction) ([]Return, error) {
+	return joinFailOnError(ctx, _self.submit, nil, funcs)
+}
+
+// JoinFailOnErrorOrTimeoutContext JoinFailOnErrorOrTimeoutContext running functions with the executor
+func (_self *Executor) JoinFailOnErrorOrTimeoutContext(ctx context.Context, duration time.Duration, funcs ...ContextFunction) ([]Return, error) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	return joinFailOnError(ctx, _self.submit, timer.C, funcs)
+}
+
+// JoinCompleteOnAnySuccessContext JoinCompleteOnAnySuccessContext running functions with the executor
+func (_self *Executor) JoinCompleteOnAnySuccessContext(ctx context.Context, funcs ...ContextFunction) ([]Return, bool) {
+	return joinCompleteOnAnySuccess(ctx, _self.submit, funcs)
+}
+
+// JoinCompleteAllContext JoinCompleteAllContext running functions with the executor
+func (_self *Executor) JoinCompleteAllContext(ctx context.Context, funcs ...ContextFunction) ([]Return, bool) {
+	returns := joinCompleteAll(ctx, _self.submit, funcs)
+	return returns, ErrorOf(returns) == nil
+}
@@ -0,0 +1,226 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyFunction return a function that record the max number of concurrent calls
+func concurrencyFunction(running *int32, maxRunning *int32) Function {
+	return func() Return {
+		current := atomic.AddInt32(running, 1)
+		for {
+			max := atomic.LoadInt32(maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(running, -1)
+		return NewReturn(nil, successValue)
+	}
+}
+
+func Test_GivenExecutorWithMaxParallelism3_WhenJoinCompleteAll_ThenRunAtMost3Functions(t *testing.T) {
+	var running, maxRunning int32
+	funcs := make([]Function, 20)
+	for index := range funcs {
+		funcs[index] = concurrencyFunction(&running, &maxRunning)
+	}
+	returns, isSuccess := NewExecutor(3, WithQueueSize(5)).JoinCompleteAll(funcs...)
+	assert.True(t, isSuccess)
+	assert.Len(t, returns, 20)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func Test_GivenFullQueueAndRejectPolicy_WhenJoinCompleteAll_ThenReturnErrQueueFull(t *testing.T) {
+	executor := NewExecutor(1, WithQueueFullPolicy(QueueFullReject))
+	returns, isSuccess := executor.JoinCompleteAll(successFunctionAfter50Ms, successFunction)
+	assert.False(t, isSuccess)
+	assert.Nil(t, returns[0].Error())
+	assert.ErrorIs(t, returns[1].Error(), ErrQueueFull)
+}
+
+func Test_GivenFullQueueAndCallerRunsPolicy_WhenJoinCompleteAll_ThenRunFunctionInCaller(t *testing.T) {
+	release := make(chan struct{})
+	executor := NewExecutor(1, WithQueueFullPolicy(QueueFullCallerRuns))
+	// second function run in the caller and release the first one, with a worker it would wait forever
+	_, isSuccess := executor.JoinCompleteAll(func() Return {
+		<-release
+		return NewReturn(nil)
+	}, func() Return {
+		close(release)
+		return NewReturn(nil)
+	})
+	assert.True(t, isSuccess)
+}
+
+func Test_GivenFullQueueAndBlockPolicy_WhenJoinCompleteAll_ThenRunAllFunctions(t *testing.T) {
+	executor := NewExecutor(0, WithQueueSize(-1))
+	returns, isSuccess := executor.JoinCompleteAll(successFunction, successFunction, successFunction, successFunction)
+	assert.True(t, isSuccess)
+	assert.Equal(t, successValue, returns[3].ReturnValues()[0])
+}
+
+func Test_GivenBlockedSubmit_WhenWorkerSlotIsReleased_ThenRunTaskInNewWorker(t *testing.T) {
+	executor := NewExecutor(1)
+	executor.workers <- struct{}{}
+	done := make(chan struct{})
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- executor.submit(context.Background(), func() {
+			close(done)
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	// without a worker receiving from the unbuffered queue only the released slot can take the task
+	<-executor.workers
+	assert.Nil(t, <-submitted)
+	<-done
+}
+
+func Test_GivenTaskQueuedWhileWorkerRelease_WhenNext_ThenTakeSlotBackAndRunTask(t *testing.T) {
+	executor := NewExecutor(1, WithQueueSize(1))
+	tasks := make(chan func(), 1)
+	go func() {
+		tasks <- executor.next()
+	}()
+	// next found the queue empty and wait to release the slot of its worker
+	time.Sleep(10 * time.Millisecond)
+	executor.queue <- func() {}
+	executor.workers <- struct{}{}
+	assert.NotNil(t, <-tasks)
+	assert.Len(t, executor.workers, 1, "the worker must hold its slot again")
+}
+
+func Test_GivenTaskQueuedWhileWorkerRelease_WhenSlotIsTaken_ThenNextReturnNil(t *testing.T) {
+	executor := NewExecutor(1, WithQueueSize(1))
+	// an unbuffered slot channel is never free, as if a new worker took the released slot
+	executor.workers = make(chan struct{})
+	tasks := make(chan func(), 1)
+	go func() {
+		tasks <- executor.next()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	executor.queue <- func() {}
+	executor.workers <- struct{}{}
+	assert.Nil(t, <-tasks)
+	assert.Len(t, executor.queue, 1, "the queued task is left to the worker holding the slot")
+}
+
+func Test_GivenQueuedFunctions_WhenJoinFailOnAnyErrorFail_ThenQueuedFunctionsAreNotCalled(t *testing.T) {
+	var calls int32
+	counterFunction := func() Return {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return NewReturn(nil)
+	}
+	executor := NewExecutor(1, WithQueueSize(10))
+	_, err := executor.JoinFailOnAnyError(errorFunction, counterFunction, counterFunction, counterFunction, counterFunction, counterFunction)
+	assert.ErrorIs(t, err, errNormal)
+	time.Sleep(100 * time.Millisecond)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(5))
+}
+
+func Test_GivenDefaultQueueSize_WhenJoinFailOnAnyErrorFail_ThenBlockedFunctionsAreNotCalled(t *testing.T) {
+	var calls int32
+	counterFunction := func() Return {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return NewReturn(nil)
+	}
+	funcs := []Function{errorFunction}
+	for index := 0; index < 20; index++ {
+		funcs = append(funcs, counterFunction)
+	}
+	_, err := NewExecutor(1).JoinFailOnAnyError(funcs...)
+	assert.ErrorIs(t, err, errNormal)
+	time.Sleep(50 * time.Millisecond)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(3))
+}
+
+func Test_GivenBlockedSubmission_WhenJoinFailOnErrorOrTimeout_ThenReturnOnTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blockedFunction := func() Return {
+		<-release
+		return NewReturn(nil)
+	}
+	start := time.Now()
+	returns, err := NewExecutor(1).JoinFailOnErrorOrTimeout(20*time.Millisecond, blockedFunction, successFunction, successFunction)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[2]))
+}
+
+func Test_GivenBlockedSubmission_WhenExecutorJoinStream_ThenReturnStreamWithoutBlocking(t *testing.T) {
+	release := make(chan struct{})
+	blockedFunction := func(ctx context.Context) Return {
+		<-release
+		return NewReturn(nil)
+	}
+	executor := NewExecutor(1)
+	stream := executor.JoinStream(context.Background(), blockedFunction, successContextFunction, successContextFunction)
+	close(release)
+	count := 0
+	for range stream {
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+func Test_GivenIdleExecutor_WhenJoinComplete_ThenNoGoroutineLeak(t *testing.T) {
+	executor := NewExecutor(2, WithQueueSize(10))
+	assertNoGoroutineLeak(t, func() {
+		executor.JoinCompleteAll(successFunction, successFunctionAfter50Ms, errorFunction, panicFunction, successFunction)
+		executor.JoinFailOnAnyError(errorFunction, successFunctionAfter50Ms, successFunctionAfter50Ms)
+	})
+}
+
+// Executor join modes tests
+
+func Test_GivenExecutor_WhenJoinModes_ThenReturnExpectedResults(t *testing.T) {
+	executor := NewExecutor(2, WithQueueSize(2))
+
+	_, err := executor.JoinFailOnAnyError(successFunction, errorFunction)
+	assert.ErrorIs(t, err, errNormal)
+
+	executor.JoinFailOnAnyErrorSuccessFailFunction(func(returns []Return) {}, func(returns []Return, err error) {
+		assert.ErrorIs(t, err, errNormal)
+	}, errorFunction)
+
+	executor.JoinCompleteAllSuccessFailFunction(func(returns []Return) {
+		assert.Len(t, returns, 1)
+	}, func(returns []Return, err error) {
+		assert.True(t, false, "JoinCompleteAllSuccessFailFunction must no call fail function")
+	}, successFunction)
+
+	_, isSuccess := executor.JoinCompleteOnAnySuccess(errorFunction, successFunction)
+	assert.True(t, isSuccess)
+
+	executor.JoinCompleteOnAnySuccessSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "JoinCompleteOnAnySuccessSuccessFailFunction must no call success function")
+	}, func(returns []Return, err error) {
+		assert.ErrorIs(t, err, errNormal)
+	}, errorFunction)
+
+	_, err = executor.JoinFailOnErrorOrTimeout(10*time.Millisecond, successFunctionAfter50Ms)
+	assert.ErrorIs(t, err, ErrTimeout)
+
+	executor.JoinFailOnErrorOrTimeoutSuccessFailFunction(func(returns []Return) {}, func(returns []Return, err error) {
+		assert.True(t, false, "JoinFailOnErrorOrTimeoutSuccessFailFunction must no call fail function")
+	}, time.Second, successFunction)
+
+	_, err = executor.JoinFailOnAnyErrorContext(context.Background(), successContextFunction)
+	assert.Nil(t, err)
+
+	_, err = executor.JoinFailOnErrorOrTimeoutContext(context.Background(), time.Second, errorContextFunction)
+	assert.ErrorIs(t, err, errNormal)
+
+	_, isSuccess = executor.JoinCompleteOnAnySuccessContext(context.Background(), successContextFunction)
+	assert.True(t, isSuccess)
+}
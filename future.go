@@ -0,0 +1,130 @@
+package gauss
+
+import (
+	"context"
+	"sync"
+)
+
+// Future handle of a function running asynchronously
+type Future struct {
+	mutex  sync.Mutex
+	done   chan struct{}
+	ret    Return
+	cancel context.CancelCauseFunc
+}
+
+// Go run function asynchronously and return a Future to await its Return
+func Go(function Function) *Future {
+	return GoContext(context.Background(), contextFunction(function))
+}
+
+// GoContext run function asynchronously and return a Future to await its Return, the context received
+// by function is cancelled when the Future is cancelled
+func GoContext(ctx context.Context, function ContextFunction) *Future {
+	ctx, cancel := context.WithCancelCause(ctx)
+	future := &Future{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		future.complete(runFunction(ctx, -1, function))
+		cancel(nil)
+	}()
+	return future
+}
+
+// complete set the Return of the future if it is not completed, return true if ret was set
+func (_self *Future) complete(ret Return) bool {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if _self.ret != nil {
+		return false
+	}
+	_self.ret = ret
+	close(_self.done)
+	return true
+}
+
+// Await wait until the future complete and return its Return
+func (_self *Future) Await() Return {
+	<-_self.done
+	return _self.ret
+}
+
+// AwaitContext wait until the future complete or ctx is done, when ctx is done return a Return with
+// StatusCancelled or StatusTimedOut, the future is not cancelled
+func (_self *Future) AwaitContext(ctx context.Context) Return {
+	select {
+	case <-_self.done:
+		return _self.ret
+	case <-ctx.Done():
+		// select choose randomly when both are ready, a completed future always return its Return
+		if ret, completed := _self.TryGet(); completed {
+			return ret
+		}
+		cause := context.Cause(ctx)
+		return newStatusReturnWithCause(statusOfCause(cause), cause)
+	}
+}
+
+// Done return a channel closed when the future complete
+func (_self *Future) Done() <-chan struct{} {
+	return _self.done
+}
+
+// TryGet return the Return and true if the future is completed, nil and false otherwise
+func (_self *Future) TryGet() (Return, bool) {
+	select {
+	case <-_self.done:
+		return _self.ret, true
+	default:
+		return nil, false
+	}
+}
+
+// Cancel complete the future with a Return with ErrCancelled and cancel the context of the function,
+// return false if the future was already completed
+func (_self *Future) Cancel() bool {
+	return _self.cancelWithCause(ErrCancelled)
+}
+
+func (_self *Future) cancelWithCause(cause error) bool {
+	if !_self.complete(newStatusReturnWithCause(statusOfCause(cause), cause)) {
+		return false
+	}
+	_self.cancel(cause)
+	return true
+}
+
+// Function return a Function that await the future, so it can be joined with other functions
+func (_self *Future) Function() Function {
+	return _self.Await
+}
+
+// ContextFunction return a ContextFunction that await the future, the future is cancelled with the
+// context cause when the context is done before the future complete
+func (_self *Future) ContextFunction() ContextFunction {
+	return func(ctx context.Context) Return {
+		select {
+		case <-_self.done:
+		case <-ctx.Done():
+			_self.cancelWithCause(context.Cause(ctx))
+		}
+		return _self.Await()
+	}
+}
+
+// FutureFunctions return a Function that await each future
+func FutureFunctions(futures ...*Future) []Function {
+	functions := make([]Function, len(futures))
+	for index, future := range futures {
+		functions[index] = future.Function()
+	}
+	return functions
+}
+
+// FutureContextFunctions return a ContextFunction that await each future
+func FutureContextFunctions(futures ...*Future) []ContextFunction {
+	functions := make([]ContextFunction, len(futures))
+	for index, future := range futures {
+		functions[index] = future.ContextFunction()
+	}
+	return functions
+}
@@ -0,0 +1,87 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSuccessFunction_WhenGoAndAwait_ThenReturnValue(t *testing.T) {
+	future := Go(successFunctionAfter50Ms)
+	_, completed := future.TryGet()
+	assert.False(t, completed, "TryGet must return false before the function complete")
+	ret := future.Await()
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	ret, completed = future.TryGet()
+	assert.True(t, completed)
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+}
+
+func Test_GivenFunctionDoPanic_WhenGo_ThenAwaitReturnPanicError(t *testing.T) {
+	future := Go(panicFunction)
+	<-future.Done()
+	assert.Equal(t, StatusPanicked, StatusOf(future.Await()))
+}
+
+func Test_GivenSlowFunction_WhenAwaitContextTimeout_ThenReturnTimedOutAndFutureContinue(t *testing.T) {
+	future := Go(successFunctionAfter50Ms)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, StatusTimedOut, StatusOf(future.AwaitContext(ctx)))
+	assert.Equal(t, StatusFulfilled, StatusOf(future.Await()))
+	for attempt := 0; attempt < 20; attempt++ {
+		assert.Equal(t, StatusFulfilled, StatusOf(future.AwaitContext(ctx)))
+	}
+}
+
+func Test_GivenRunningFunction_WhenCancel_ThenCompleteCancelledAndCancelContext(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	future := GoContext(context.Background(), causeContextFunction(started, causes))
+	<-started
+	assert.True(t, future.Cancel())
+	assert.ErrorIs(t, future.Await().Error(), ErrCancelled)
+	assert.Equal(t, StatusCancelled, StatusOf(future.Await()))
+	assert.ErrorIs(t, <-causes, ErrCancelled)
+}
+
+func Test_GivenCompletedFuture_WhenCancel_ThenReturnFalse(t *testing.T) {
+	future := Go(successFunction)
+	future.Await()
+	assert.False(t, future.Cancel())
+	assert.Equal(t, StatusFulfilled, StatusOf(future.Await()))
+}
+
+// Join futures tests
+
+func Test_GivenFutures_WhenJoinCompleteAll_ThenReturnFutureReturns(t *testing.T) {
+	first, second := Go(successFunction), Go(errorFunction)
+	returns, isSuccess := JoinCompleteAll(FutureFunctions(first, second)...)
+	assert.False(t, isSuccess)
+	assert.Equal(t, successValue, returns[0].ReturnValues()[0])
+	assert.ErrorIs(t, returns[1].Error(), errNormal)
+}
+
+func Test_GivenFutures_WhenJoinFailOnAnyErrorContextFail_ThenCancelRunningFutures(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	running := GoContext(context.Background(), causeContextFunction(started, causes))
+	failing := GoContext(context.Background(), afterStarted(started, errorContextFunction))
+	_, err := JoinFailOnAnyErrorContext(context.Background(), FutureContextFunctions(running, failing)...)
+	assert.ErrorIs(t, err, errNormal)
+	assert.ErrorIs(t, <-causes, errNormal, "running future must be cancelled with the join cause")
+	assert.Equal(t, StatusCancelled, StatusOf(running.Await()))
+}
+
+func Test_GivenPanicFutures_WhenJoinFutureFunctions_ThenPanicErrorHasSlotIndexAndFutureIsUnchanged(t *testing.T) {
+	future := Go(panicFunction)
+	returns, _ := JoinCompleteAll(FutureFunctions(future, future)...)
+	for index, ret := range returns {
+		var panicError *PanicError
+		assert.ErrorAs(t, ret.Error(), &panicError)
+		assert.Equal(t, index, panicError.Index)
+	}
+	var panicError *PanicError
+	assert.ErrorAs(t, future.Await().Error(), &panicError)
+	assert.Equal(t, -1, panicError.Index, "the shared Return of the future must not be changed")
+}
@@ -0,0 +1,300 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrDuplicateNode error returned by Graph.Build when two nodes have the same name
+	ErrDuplicateNode = errors.New("duplicate node")
+	// ErrUnknownDependency error returned by Graph.Build when a node depends on a node not in the graph
+	ErrUnknownDependency = errors.New("unknown dependency")
+	// ErrGraphCycle error returned by Graph.Build when the dependencies of the nodes have a cycle
+	ErrGraphCycle = errors.New("graph cycle")
+	// ErrDependencyFailed error of the nodes not run with GraphContinueOnError because a dependency fail
+	ErrDependencyFailed = errors.New("dependency failed")
+)
+
+// GraphFunction function of a graph node, dependencies contains the Return of each dependency by name
+type GraphFunction func(ctx context.Context, dependencies map[string]Return) Return
+
+// TypedGraphFunction typed function of a graph node
+type TypedGraphFunction[T any] func(ctx context.Context, dependencies map[string]Return) (T, error)
+
+// GraphPolicy behaviour of DAG.Run when a node fail
+type GraphPolicy int
+
+const (
+	// GraphFailFast return as soon as any node fail, the context of running nodes is cancelled with the error
+	// as cause and nodes not completed are StatusPending
+	GraphFailFast GraphPolicy = iota
+	// GraphContinueOnError run every node whose dependencies success, nodes with a failed dependency are not
+	// run and their error wrap ErrDependencyFailed
+	GraphContinueOnError
+)
+
+// JoinMode join used by a stage to run its functions
+type JoinMode int
+
+const (
+	// JoinModeFailOnAnyError stage fail as soon as any function fail, like JoinFailOnAnyErrorContext
+	JoinModeFailOnAnyError JoinMode = iota
+	// JoinModeCompleteOnAnySuccess stage success as soon as any function success, like JoinCompleteOnAnySuccessContext
+	JoinModeCompleteOnAnySuccess
+	// JoinModeCompleteAll stage wait all functions, like JoinCompleteAllContext, and fail if any function fail
+	JoinModeCompleteAll
+)
+
+type graphNode struct {
+	name         string
+	dependencies []string
+	function     GraphFunction
+}
+
+// Graph builder of a DAG, nodes are added with their dependencies and validated by Build
+type Graph struct {
+	nodes []*graphNode
+}
+
+// NewGraph create an empty Graph
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// AddNode add a node that run function once all its dependencies complete
+func (_self *Graph) AddNode(name string, function GraphFunction, dependencies ...string) *Graph {
+	_self.nodes = append(_self.nodes, &graphNode{name: name, dependencies: dependencies, function: function})
+	return _self
+}
+
+// Add add a node that run function once all its dependencies complete
+func (_self *Graph) Add(name string, function Function, dependencies ...string) *Graph {
+	return _self.AddContext(name, contextFunction(function), dependencies...)
+}
+
+// AddContext add a node that run function once all its dependencies complete
+func (_self *Graph) AddContext(name string, function ContextFunction, dependencies ...string) *Graph {
+	return _self.AddNode(name, func(ctx context.Context, _ map[string]Return) Return {
+		return function(ctx)
+	}, dependencies...)
+}
+
+// AddStage add a node that run funcs with the join mode once all its dependencies complete, the ReturnValues
+// of the stage contains the Return of each function
+func (_self *Graph) AddStage(name string, mode JoinMode, dependencies []string, funcs ...GraphFunction) *Graph {
+	return _self.AddNode(name, func(ctx context.Context, returns map[string]Return) Return {
+		stageFuncs := make([]ContextFunction, len(funcs))
+		for index, function := range funcs {
+			function := function
+			stageFuncs[index] = func(ctx context.Context) Return {
+				return function(ctx, returns)
+			}
+		}
+		return stageReturn(joinStage(ctx, mode, stageFuncs))
+	}, dependencies...)
+}
+
+// AddTyped add a node that run a typed function once all its dependencies complete, use ResultOf to read
+// its value from the dependencies of other nodes
+func AddTyped[T any](graph *Graph, name string, function TypedGraphFunction[T], dependencies ...string) *Graph {
+	return graph.AddNode(name, func(ctx context.Context, returns map[string]Return) Return {
+		value, err := function(ctx, returns)
+		return &typedReturn[T]{result[T]{value: value, err: err}}
+	}, dependencies...)
+}
+
+// joinStage run the functions of a stage with mode, stage functions run in their own goroutines so an
+// executor running the graph can not be exhausted by its own stages
+func joinStage(ctx context.Context, mode JoinMode, funcs []ContextFunction) ([]Return, error) {
+	switch mode {
+	case JoinModeCompleteOnAnySuccess:
+		returns, ok := joinCompleteOnAnySuccess(ctx, spawnGoroutine, funcs)
+		if ok {
+			return returns, nil
+		}
+		return returns, ErrorOf(returns)
+	case JoinModeCompleteAll:
+		returns := joinCompleteAll(ctx, spawnGoroutine, funcs)
+		return returns, ErrorOf(returns)
+	default:
+		return joinFailOnError(ctx, spawnGoroutine, nil, funcs)
+	}
+}
+
+func stageReturn(returns []Return, err error) Return {
+	returnValues := make([]interface{}, len(returns))
+	for index, ret := range returns {
+		returnValues[index] = ret
+	}
+	return NewReturn(err, returnValues...)
+}
+
+// Build validate the graph and return a DAG that can be run many times, return an error when a name is
+// duplicated, a dependency is unknown or the dependencies have a cycle
+func (_self *Graph) Build() (*DAG, error) {
+	nodes := make([]*graphNode, len(_self.nodes))
+	copy(nodes, _self.nodes)
+	indexes := make(map[string]int, len(nodes))
+	for index, node := range nodes {
+		if _, ok := indexes[node.name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateNode, node.name)
+		}
+		indexes[node.name] = index
+	}
+	dependencies := make([][]int, len(nodes))
+	dependents := make([][]int, len(nodes))
+	for index, node := range nodes {
+		for _, name := range node.dependencies {
+			dependency, ok := indexes[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q depends on %q", ErrUnknownDependency, node.name, name)
+			}
+			dependencies[index] = append(dependencies[index], dependency)
+			dependents[dependency] = append(dependents[dependency], index)
+		}
+	}
+	// Kahn's algorithm, nodes never ready are part of a cycle or depend on one
+	pending := make([]int, len(nodes))
+	var ready []int
+	for index := range nodes {
+		pending[index] = len(dependencies[index])
+		if pending[index] == 0 {
+			ready = append(ready, index)
+		}
+	}
+	for visited := 0; visited < len(ready); visited++ {
+		for _, dependent := range dependents[ready[visited]] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(ready) < len(nodes) {
+		var names []string
+		for index, node := range nodes {
+			if pending[index] > 0 {
+				names = append(names, node.name)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrGraphCycle, strings.Join(names, ", "))
+	}
+	return &DAG{nodes: nodes, dependencies: dependencies, dependents: dependents}, nil
+}
+
+// DAG validated graph, each node is run as soon as all its dependencies complete
+type DAG struct {
+	nodes        []*graphNode
+	dependencies [][]int
+	dependents   [][]int
+}
+
+// runGraph run the nodes of dag with maximal parallelism, a node is submitted once all its dependencies
+// complete. Return the Return of each node by name and the error of the run
+func runGraph(parent context.Context, submit submitFunction, dag *DAG, policy GraphPolicy) (map[string]Return, error) {
+	if parent.Err() != nil {
+		cause := context.Cause(parent)
+		return dag.returnsByName(doneReturns(len(dag.nodes), cause)), cause
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	returns := make([]Return, len(dag.nodes))
+	pending := make([]int, len(dag.nodes))
+	completions := make(chan completion, len(dag.nodes))
+	running := 0
+	start := func(index int) {
+		node := dag.nodes[index]
+		dependencies := make(map[string]Return, len(node.dependencies))
+		for _, dependency := range dag.dependencies[index] {
+			dependencies[dag.nodes[dependency].name] = returns[dependency]
+		}
+		function := func(ctx context.Context) Return {
+			return node.function(ctx, dependencies)
+		}
+		running++
+		go submitTask(ctx, submit, index, function, completions)
+	}
+	var complete func(index int, ret Return)
+	complete = func(index int, ret Return) {
+		returns[index] = ret
+		for _, dependent := range dag.dependents[index] {
+			pending[dependent]--
+			if pending[dependent] > 0 {
+				continue
+			}
+			if err := dag.dependencyError(dependent, returns); err != nil {
+				complete(dependent, NewReturn(err))
+			} else {
+				start(dependent)
+			}
+		}
+	}
+	for index := range dag.nodes {
+		pending[index] = len(dag.dependencies[index])
+		if pending[index] == 0 {
+			start(index)
+		}
+	}
+	for running > 0 {
+		select {
+		case c := <-completions:
+			running--
+			if err := c.ret.Error(); err != nil && policy == GraphFailFast {
+				returns[c.index] = c.ret
+				cancel(err)
+				return dag.returnsByName(fillIncomplete(returns, StatusPending)), err
+			}
+			complete(c.index, c.ret)
+		case <-ctx.Done():
+			cause := context.Cause(ctx)
+			return dag.returnsByName(fillIncomplete(returns, statusOfCause(cause))), cause
+		}
+	}
+	return dag.returnsByName(returns), dag.errorOf(returns)
+}
+
+// errorOf ErrorOf with the name of the node as name of each *FunctionError
+func (_self *DAG) errorOf(returns []Return) error {
+	err := ErrorOf(returns)
+	var multiError *MultiError
+	if errors.As(err, &multiError) {
+		for _, functionError := range multiError.Errors {
+			functionError.Name = _self.nodes[functionError.Index].name
+		}
+	}
+	return err
+}
+
+// dependencyError return an error wrapping ErrDependencyFailed and the error of the first failed dependency
+// of the node with index, nil if all dependencies success
+func (_self *DAG) dependencyError(index int, returns []Return) error {
+	for _, dependency := range _self.dependencies[index] {
+		if err := returns[dependency].Error(); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrDependencyFailed, _self.nodes[dependency].name, err)
+		}
+	}
+	return nil
+}
+
+func (_self *DAG) returnsByName(returns []Return) map[string]Return {
+	returnsByName := make(map[string]Return, len(returns))
+	for index, ret := range returns {
+		returnsByName[_self.nodes[index].name] = ret
+	}
+	return returnsByName
+}
+
+// Run run the nodes with maximal parallelism and return the Return of each node by name. With GraphFailFast
+// return the error of the first failed node, with GraphContinueOnError a *MultiError with the error of each
+// failed node
+func (_self *DAG) Run(ctx context.Context, policy GraphPolicy) (map[string]Return, error) {
+	return runGraph(ctx, spawnGoroutine, _self, policy)
+}
+
+// RunGraph DAG.Run running nodes with the executor
+func (_self *Executor) RunGraph(ctx context.Context, dag *DAG, policy GraphPolicy) (map[string]Return, error) {
+	return runGraph(ctx, _self.submit, dag, policy)
+}
@@ -0,0 +1,189 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intNode(value int) TypedGraphFunction[int] {
+	return func(ctx context.Context, dependencies map[string]Return) (int, error) {
+		return value, nil
+	}
+}
+
+func sumNode(ctx context.Context, dependencies map[string]Return) (int, error) {
+	sum := 0
+	for _, ret := range dependencies {
+		sum += ResultOf[int](ret).Value()
+	}
+	return sum, nil
+}
+
+func Test_GivenDiamondGraph_WhenRun_ThenNodesReadReturnOfDependencies(t *testing.T) {
+	graph := NewGraph()
+	AddTyped(graph, "config", intNode(1))
+	AddTyped(graph, "a", sumNode, "config")
+	AddTyped(graph, "b", sumNode, "config")
+	AddTyped(graph, "merge", sumNode, "a", "b")
+	dag, err := graph.Build()
+	assert.Nil(t, err)
+	for run := 0; run < 2; run++ {
+		returns, err := dag.Run(context.Background(), GraphFailFast)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, ResultOf[int](returns["merge"]).Value())
+	}
+}
+
+func Test_GivenIndependentNodes_WhenRun_ThenRunInParallel(t *testing.T) {
+	dag, _ := NewGraph().
+		Add("a", successFunctionAfter50Ms).
+		Add("b", successFunctionAfter50Ms).
+		Add("c", successFunctionAfter50Ms).
+		Build()
+	start := time.Now()
+	returns, err := dag.Run(context.Background(), GraphFailFast)
+	assert.Nil(t, err)
+	assert.Less(t, time.Since(start), 140*time.Millisecond)
+	assert.Len(t, returns, 3)
+}
+
+func Test_GivenInvalidGraph_WhenBuild_ThenReturnError(t *testing.T) {
+	_, err := NewGraph().Add("a", successFunction).Add("a", successFunction).Build()
+	assert.ErrorIs(t, err, ErrDuplicateNode)
+	_, err = NewGraph().Add("a", successFunction, "missing").Build()
+	assert.ErrorIs(t, err, ErrUnknownDependency)
+	_, err = NewGraph().
+		Add("a", successFunction, "b").
+		Add("b", successFunction, "a").
+		Add("c", successFunction).
+		Build()
+	assert.ErrorIs(t, err, ErrGraphCycle)
+	assert.Equal(t, "graph cycle: a, b", err.Error())
+}
+
+func Test_GivenFailingNode_WhenRunGraphFailFast_ThenCancelRunningNodesAndReturnError(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	dag, _ := NewGraph().
+		AddContext("a", afterStarted(started, errorContextFunction)).
+		AddContext("b", causeContextFunction(started, causes)).
+		Add("c", successFunction, "a").
+		Build()
+	returns, err := dag.Run(context.Background(), GraphFailFast)
+	assert.ErrorIs(t, err, errNormal)
+	assert.ErrorIs(t, <-causes, errNormal)
+	assert.ErrorIs(t, returns["a"].Error(), errNormal)
+	assert.Equal(t, StatusPending, StatusOf(returns["c"]))
+}
+
+func Test_GivenFailingNode_WhenRunGraphContinueOnError_ThenSkipDependents(t *testing.T) {
+	dag, _ := NewGraph().
+		Add("a", errorFunction).
+		Add("b", successFunction).
+		Add("c", successFunction, "a", "b").
+		Add("d", successFunction, "c").
+		Add("e", successFunction, "b").
+		Build()
+	returns, err := dag.Run(context.Background(), GraphContinueOnError)
+	var multiError *MultiError
+	assert.ErrorAs(t, err, &multiError)
+	assert.Len(t, multiError.Errors, 3)
+	assert.Equal(t, "a", multiError.Errors[0].Name)
+	assert.Nil(t, returns["b"].Error())
+	assert.Nil(t, returns["e"].Error())
+	assert.ErrorIs(t, returns["c"].Error(), ErrDependencyFailed)
+	assert.ErrorIs(t, returns["c"].Error(), errNormal)
+	assert.ErrorIs(t, returns["d"].Error(), ErrDependencyFailed)
+}
+
+func Test_GivenPanicNode_WhenRun_ThenReturnPanicError(t *testing.T) {
+	dag, _ := NewGraph().Add("a", panicFunction).Build()
+	returns, err := dag.Run(context.Background(), GraphFailFast)
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+	assert.Equal(t, StatusPanicked, StatusOf(returns["a"]))
+}
+
+func Test_GivenStages_WhenRun_ThenRunStageFunctionsWithJoinMode(t *testing.T) {
+	success := func(ctx context.Context, dependencies map[string]Return) Return {
+		return NewReturn(nil, len(dependencies))
+	}
+	fail := func(ctx context.Context, dependencies map[string]Return) Return {
+		return NewReturn(errNormal)
+	}
+	dag, _ := NewGraph().
+		Add("config", successFunction).
+		AddStage("all", JoinModeCompleteAll, []string{"config"}, success, success).
+		AddStage("any", JoinModeCompleteOnAnySuccess, []string{"config"}, fail, success).
+		AddStage("none", JoinModeCompleteOnAnySuccess, []string{"config"}, fail).
+		AddStage("failFast", JoinModeFailOnAnyError, []string{"config"}, success, fail).
+		AddStage("allFail", JoinModeCompleteAll, nil, success, fail).
+		Build()
+	returns, _ := dag.Run(context.Background(), GraphContinueOnError)
+	assert.Nil(t, returns["all"].Error())
+	assert.Len(t, returns["all"].ReturnValues(), 2)
+	assert.Equal(t, 1, returns["all"].ReturnValues()[0].(Return).ReturnValues()[0])
+	assert.Nil(t, returns["any"].Error())
+	assert.ErrorIs(t, returns["none"].Error(), errNormal)
+	assert.ErrorIs(t, returns["failFast"].Error(), errNormal)
+	assert.ErrorIs(t, returns["allFail"].Error(), errNormal)
+}
+
+func Test_GivenContextCancelled_WhenRun_ThenReturnCancelledNodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started, causes := make(chan struct{}), make(chan error, 1)
+	dag, _ := NewGraph().
+		AddContext("a", causeContextFunction(started, causes)).
+		Add("b", successFunction, "a").
+		Build()
+	go func() {
+		<-started
+		cancel()
+	}()
+	returns, err := dag.Run(ctx, GraphContinueOnError)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, <-causes, context.Canceled)
+	assert.Equal(t, StatusCancelled, StatusOf(returns["b"]))
+	returns, err = dag.Run(ctx, GraphContinueOnError)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StatusCancelled, StatusOf(returns["a"]))
+}
+
+func Test_GivenExecutor_WhenRunGraph_ThenRunNodesWithExecutor(t *testing.T) {
+	dag, _ := NewGraph().Add("a", successFunctionAfter50Ms).Add("b", successFunctionAfter50Ms).Build()
+	returns, err := NewExecutor(1, WithQueueFullPolicy(QueueFullReject)).RunGraph(context.Background(), dag, GraphContinueOnError)
+	assert.ErrorIs(t, err, ErrQueueFull)
+	// ready nodes are submitted concurrently, one of them is rejected
+	assert.True(t, (returns["a"].Error() == nil) != (returns["b"].Error() == nil))
+	returns, err = NewExecutor(2).RunGraph(context.Background(), dag, GraphFailFast)
+	assert.Nil(t, err)
+	assert.Len(t, returns, 2)
+}
+
+func Test_GivenRateLimitedExecutor_WhenRunGraphFailFast_ThenReturnWithoutWaitingSubmits(t *testing.T) {
+	var failed, startedAfterFailure int32
+	var failedAt time.Time
+	graph := NewGraph().Add("fail", func() Return {
+		failedAt = time.Now()
+		atomic.StoreInt32(&failed, 1)
+		return NewReturn(errNormal)
+	})
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		graph.Add(name, func() Return {
+			if atomic.LoadInt32(&failed) == 1 {
+				atomic.AddInt32(&startedAfterFailure, 1)
+			}
+			return NewReturn(nil)
+		})
+	}
+	dag, _ := graph.Build()
+	executor := NewExecutor(10, WithRateLimiter(NewTokenBucket(20, 1)))
+	returns, err := executor.RunGraph(context.Background(), dag, GraphFailFast)
+	assert.ErrorIs(t, err, errNormal)
+	assert.Less(t, time.Since(failedAt), 30*time.Millisecond, "fail-fast must not wait pending submits")
+	assert.LessOrEqual(t, atomic.LoadInt32(&startedAfterFailure), int32(1))
+	assert.ErrorIs(t, returns["fail"].Error(), errNormal)
+}
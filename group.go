@@ -0,0 +1,125 @@
+package gauss
+
+import (
+	"context"
+	"sync"
+)
+
+// groupCall execution shared by the waiters of a key
+type groupCall struct {
+	done    chan struct{}
+	ret     Return
+	waiters int
+	cancel  context.CancelCauseFunc
+}
+
+// Group collapse concurrent calls with the same key into one execution whose Return is shared by all waiters
+type Group struct {
+	mutex sync.Mutex
+	calls map[string]*groupCall
+}
+
+// NewGroup create an empty Group
+func NewGroup() *Group {
+	return &Group{calls: map[string]*groupCall{}}
+}
+
+// join add a waiter to the execution of key, start the execution when there is none
+func (_self *Group) join(key string, function ContextFunction) *groupCall {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	call, ok := _self.calls[key]
+	if !ok {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		call = &groupCall{done: make(chan struct{}), cancel: cancel}
+		_self.calls[key] = call
+		go _self.run(ctx, key, call, function)
+	}
+	call.waiters++
+	return call
+}
+
+func (_self *Group) run(ctx context.Context, key string, call *groupCall, function ContextFunction) {
+	call.ret = runFunction(ctx, -1, function)
+	_self.forget(key, call)
+	call.cancel(nil)
+	close(call.done)
+}
+
+// forget remove call from the group if it is still the execution of key
+func (_self *Group) forget(key string, call *groupCall) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if _self.calls[key] == call {
+		delete(_self.calls, key)
+	}
+}
+
+// leave remove a waiter, the execution is cancelled with cause when the last waiter leave
+func (_self *Group) leave(key string, call *groupCall, cause error) {
+	_self.mutex.Lock()
+	call.waiters--
+	last := call.waiters == 0
+	// removed while locked, so a new call of key can not join the cancelled execution
+	if last && _self.calls[key] == call {
+		delete(_self.calls, key)
+	}
+	_self.mutex.Unlock()
+	if last {
+		call.cancel(cause)
+	}
+}
+
+func (_self *Group) wait(ctx context.Context, key string, function ContextFunction) Return {
+	if ctx.Err() != nil {
+		return doneReturns(1, context.Cause(ctx))[0]
+	}
+	call := _self.join(key, function)
+	select {
+	case <-call.done:
+		return call.ret
+	case <-ctx.Done():
+		select {
+		case <-call.done:
+			return call.ret
+		default:
+		}
+		cause := context.Cause(ctx)
+		_self.leave(key, call, cause)
+		return newStatusReturnWithCause(statusOfCause(cause), cause)
+	}
+}
+
+// Do call function unless an execution of key is running, in that case wait it, and return the Return of
+// the execution. A panic is returned as a *PanicError to every waiter
+func (_self *Group) Do(key string, function Function) Return {
+	return _self.wait(context.Background(), key, contextFunction(function))
+}
+
+// DoContext Do that return when the context is done, the shared execution is cancelled with the context
+// cause only when every waiter left
+func (_self *Group) DoContext(ctx context.Context, key string, function ContextFunction) Return {
+	return _self.wait(ctx, key, function)
+}
+
+// DoChan Do that send the Return to the returned channel
+func (_self *Group) DoChan(key string, function Function) <-chan Return {
+	return _self.DoChanContext(context.Background(), key, contextFunction(function))
+}
+
+// DoChanContext DoContext that send the Return to the returned channel
+func (_self *Group) DoChanContext(ctx context.Context, key string, function ContextFunction) <-chan Return {
+	returns := make(chan Return, 1)
+	go func() {
+		returns <- _self.wait(ctx, key, function)
+	}()
+	return returns
+}
+
+// Forget forget the execution of key, next calls start a new execution while current waiters still receive
+// the Return of the forgotten one
+func (_self *Group) Forget(key string) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	delete(_self.calls, key)
+}
@@ -0,0 +1,143 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitWaiters poll group until the execution of key has count waiters
+func waitWaiters(group *Group, key string, count int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		group.mutex.Lock()
+		call, ok := group.calls[key]
+		waiters := 0
+		if ok {
+			waiters = call.waiters
+		}
+		group.mutex.Unlock()
+		if waiters == count {
+			return
+		}
+	}
+}
+
+// countedFunction return a function that count its calls and wait release
+func countedFunction(calls *int32, release chan struct{}) ContextFunction {
+	return func(ctx context.Context) Return {
+		atomic.AddInt32(calls, 1)
+		select {
+		case <-release:
+			return NewReturn(nil, successValue)
+		case <-ctx.Done():
+			return NewReturn(context.Cause(ctx))
+		}
+	}
+}
+
+func Test_GivenConcurrentCallsWithSameKey_WhenDo_ThenRunOnceAndShareReturn(t *testing.T) {
+	group := NewGroup()
+	var calls int32
+	release := make(chan struct{})
+	function := countedFunction(&calls, release)
+	returns := make([]<-chan Return, 5)
+	for index := range returns {
+		returns[index] = group.DoChan("key", func() Return {
+			return function(context.Background())
+		})
+	}
+	waitWaiters(group, "key", 5)
+	close(release)
+	for _, ret := range returns {
+		assert.Equal(t, successValue, (<-ret).ReturnValues()[0])
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, successValue, group.Do("key", successFunction).ReturnValues()[0], "completed execution must be forgotten")
+}
+
+func Test_GivenPanicFunction_WhenDo_ThenReturnPanicErrorToWaiters(t *testing.T) {
+	ret := NewGroup().Do("key", panicFunction)
+	assert.Equal(t, StatusPanicked, StatusOf(ret))
+}
+
+func Test_GivenForgottenKey_WhenDo_ThenStartNewExecution(t *testing.T) {
+	group := NewGroup()
+	var calls int32
+	release := make(chan struct{})
+	first := group.DoChanContext(context.Background(), "key", countedFunction(&calls, release))
+	waitWaiters(group, "key", 1)
+	group.Forget("key")
+	second := group.DoChanContext(context.Background(), "key", countedFunction(&calls, release))
+	waitWaiters(group, "key", 1)
+	close(release)
+	assert.Nil(t, (<-first).Error())
+	assert.Nil(t, (<-second).Error())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenWaiterAbandon_WhenOtherWaitersRemain_ThenExecutionContinue(t *testing.T) {
+	group := NewGroup()
+	var calls int32
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	abandoned := group.DoChanContext(ctx, "key", countedFunction(&calls, release))
+	remaining := group.DoChanContext(context.Background(), "key", countedFunction(&calls, release))
+	waitWaiters(group, "key", 2)
+	cancel()
+	assert.Equal(t, StatusCancelled, StatusOf(<-abandoned))
+	close(release)
+	assert.Equal(t, successValue, (<-remaining).ReturnValues()[0])
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenAllWaitersAbandon_WhenDoContext_ThenCancelExecutionWithCause(t *testing.T) {
+	group := NewGroup()
+	started, causes := make(chan struct{}), make(chan error, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ret := group.DoContext(ctx, "key", causeContextFunction(started, causes))
+	assert.Equal(t, StatusTimedOut, StatusOf(ret))
+	assert.ErrorIs(t, <-causes, context.DeadlineExceeded)
+	assert.Equal(t, successValue, group.Do("key", successFunction).ReturnValues()[0], "cancelled execution must be forgotten")
+}
+
+func Test_GivenCancelledContext_WhenDoContext_ThenDoNotCallFunction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ret := NewGroup().DoContext(ctx, "key", func(ctx context.Context) Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})
+	assert.Equal(t, StatusCancelled, StatusOf(ret))
+}
+
+// doneContext context whose Done channel is closed but Err is nil, simulate a context done between the
+// check of DoContext and the wait
+type doneContext struct {
+	context.Context
+	done chan struct{}
+}
+
+func (_self *doneContext) Done() <-chan struct{} {
+	return _self.done
+}
+
+func (_self *doneContext) Err() error {
+	return nil
+}
+
+func Test_GivenCompletedExecution_WhenContextDoneAtTheSameTime_ThenReturnExecutionReturn(t *testing.T) {
+	group := NewGroup()
+	call := &groupCall{done: make(chan struct{}), ret: NewReturn(nil, successValue), cancel: func(error) {}}
+	close(call.done)
+	group.calls["key"] = call
+	ctx := &doneContext{Context: context.Background(), done: make(chan struct{})}
+	close(ctx.done)
+	for attempt := 0; attempt < 20; attempt++ {
+		ret := group.DoContext(ctx, "key", successContextFunction)
+		assert.Equal(t, successValue, ret.ReturnValues()[0])
+	}
+}
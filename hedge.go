@@ -0,0 +1,162 @@
+package gauss
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// joinHedged call function and call it again each time delay elapse without a success, at most maxHedges
+// extra calls. When every running call fail the next one is started without wait. Return the first success
+// and its duration and cancel the other calls with ErrSuccessFound as cause
+func joinHedged(parent context.Context, submit submitFunction, delay time.Duration, maxHedges int, function ContextFunction) (Return, time.Duration) {
+	if parent.Err() != nil {
+		return doneReturns(1, context.Cause(parent))[0], 0
+	}
+	if maxHedges < 0 {
+		maxHedges = 0
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	completions := make(chan completion, maxHedges+1)
+	returns := make([]Return, 0, maxHedges+1)
+	launch := func() {
+		index := len(returns)
+		returns = append(returns, nil)
+		go submitTask(ctx, submit, index, function, completions)
+	}
+	launch()
+	running := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case c := <-completions:
+			if c.ret.Error() == nil {
+				cancel(ErrSuccessFound)
+				return c.ret, c.duration
+			}
+			returns[c.index] = c.ret
+			running--
+			if running > 0 {
+				continue
+			}
+			if len(returns) > maxHedges {
+				err := ErrorOf(returns)
+				cancel(err)
+				return NewReturn(err), 0
+			}
+			launch()
+			running++
+		case <-timer.C:
+			if len(returns) <= maxHedges {
+				launch()
+				running++
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			cause := context.Cause(ctx)
+			cancel(cause)
+			return newStatusReturnWithCause(statusOfCause(cause), cause), 0
+		}
+	}
+}
+
+// JoinHedged call function and call a copy each time delay elapse without a result, at most maxHedges copies.
+// Return the first success, when all calls fail the Return contains a *MultiError with the error of each call
+func JoinHedged(delay time.Duration, maxHedges int, function Function) Return {
+	ret, _ := joinHedged(context.Background(), spawnGoroutine, delay, maxHedges, contextFunction(function))
+	return ret
+}
+
+// JoinHedgedContext call function and call a copy each time delay elapse without a result, at most maxHedges
+// copies. Return the first success and cancel the context of the other calls with ErrSuccessFound as cause
+func JoinHedgedContext(ctx context.Context, delay time.Duration, maxHedges int, function ContextFunction) Return {
+	ret, _ := joinHedged(ctx, spawnGoroutine, delay, maxHedges, function)
+	return ret
+}
+
+// JoinHedged JoinHedged running calls with the executor
+func (_self *Executor) JoinHedged(delay time.Duration, maxHedges int, function Function) Return {
+	ret, _ := joinHedged(context.Background(), _self.submit, delay, maxHedges, contextFunction(function))
+	return ret
+}
+
+// JoinHedgedContext JoinHedgedContext running calls with the executor
+func (_self *Executor) JoinHedgedContext(ctx context.Context, delay time.Duration, maxHedges int, function ContextFunction) Return {
+	ret, _ := joinHedged(ctx, _self.submit, delay, maxHedges, function)
+	return ret
+}
+
+// AdaptiveHedger hedge calls with a delay equals to a percentile of the latency of the last successful calls
+type AdaptiveHedger struct {
+	mutex        sync.Mutex
+	latencies    []time.Duration
+	next         int
+	percentile   float64
+	initialDelay time.Duration
+	maxHedges    int
+}
+
+// NewAdaptiveHedger create an AdaptiveHedger that use the percentile, between 0 and 1, of the latency of the
+// last window successful calls as delay, initialDelay is used until a latency is observed
+func NewAdaptiveHedger(percentile float64, window int, initialDelay time.Duration, maxHedges int) *AdaptiveHedger {
+	if window < 1 {
+		window = 1
+	}
+	return &AdaptiveHedger{
+		latencies:    make([]time.Duration, 0, window),
+		percentile:   percentile,
+		initialDelay: initialDelay,
+		maxHedges:    maxHedges,
+	}
+}
+
+// Delay return the current delay before start a hedged call
+func (_self *AdaptiveHedger) Delay() time.Duration {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if len(_self.latencies) == 0 {
+		return _self.initialDelay
+	}
+	sorted := make([]time.Duration, len(_self.latencies))
+	copy(sorted, _self.latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	index := int(math.Ceil(_self.percentile*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// Observe record the latency of a successful call
+func (_self *AdaptiveHedger) Observe(latency time.Duration) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if len(_self.latencies) < cap(_self.latencies) {
+		_self.latencies = append(_self.latencies, latency)
+		return
+	}
+	_self.latencies[_self.next] = latency
+	_self.next = (_self.next + 1) % len(_self.latencies)
+}
+
+// JoinHedged JoinHedged with the adaptive delay
+func (_self *AdaptiveHedger) JoinHedged(function Function) Return {
+	return _self.JoinHedgedContext(context.Background(), contextFunction(function))
+}
+
+// JoinHedgedContext JoinHedgedContext with the adaptive delay
+func (_self *AdaptiveHedger) JoinHedgedContext(ctx context.Context, function ContextFunction) Return {
+	ret, latency := joinHedged(ctx, spawnGoroutine, _self.Delay(), _self.maxHedges, function)
+	if ret.Error() == nil {
+		_self.Observe(latency)
+	}
+	return ret
+}
@@ -0,0 +1,148 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// slowFirstCallFunction return a function whose first call wait until its context is done and send the
+// cause, next calls succeed immediately
+func slowFirstCallFunction(causes chan error) ContextFunction {
+	var calls int32
+	return func(ctx context.Context) Return {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return NewReturn(ctx.Err())
+		}
+		return NewReturn(nil, successValue)
+	}
+}
+
+func Test_GivenSlowFirstCall_WhenJoinHedgedContext_ThenReturnHedgeSuccessAndCancelFirstCall(t *testing.T) {
+	causes := make(chan error, 1)
+	ret := JoinHedgedContext(context.Background(), 10*time.Millisecond, 1, slowFirstCallFunction(causes))
+	assert.Nil(t, ret.Error())
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	assert.ErrorIs(t, <-causes, ErrSuccessFound)
+}
+
+func Test_GivenFastFunction_WhenJoinHedged_ThenReturnSuccessWithoutHedge(t *testing.T) {
+	var calls int32
+	ret := JoinHedged(time.Second, 2, func() Return {
+		atomic.AddInt32(&calls, 1)
+		return NewReturn(nil, successValue)
+	})
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenFailingFunction_WhenJoinHedged_ThenReturnMultiErrorOfEachCall(t *testing.T) {
+	ret := JoinHedged(time.Second, 2, errorFunction)
+	var multiError *MultiError
+	assert.ErrorAs(t, ret.Error(), &multiError)
+	assert.Len(t, multiError.Errors, 3)
+	assert.ErrorIs(t, ret.Error(), errNormal)
+}
+
+func Test_GivenNoHedgesLeft_WhenDelayElapse_ThenWaitRunningCall(t *testing.T) {
+	ret := JoinHedged(10*time.Millisecond, -1, successFunctionAfter50Ms)
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+}
+
+func Test_GivenContextCancelled_WhenJoinHedgedContext_ThenReturnCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started, causes := make(chan struct{}), make(chan error, 1)
+	go func() {
+		<-started
+		cancel()
+	}()
+	ret := JoinHedgedContext(ctx, time.Second, 1, causeContextFunction(started, causes))
+	assert.Equal(t, StatusCancelled, StatusOf(ret))
+	assert.ErrorIs(t, <-causes, context.Canceled)
+}
+
+func Test_GivenCancelledContext_WhenJoinHedgedContext_ThenDoNotCallFunction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ret := JoinHedgedContext(ctx, time.Second, 1, func(ctx context.Context) Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})
+	assert.Equal(t, StatusCancelled, StatusOf(ret))
+}
+
+func Test_GivenExecutor_WhenJoinHedged_ThenRunCallsWithExecutor(t *testing.T) {
+	executor := NewExecutor(1, WithQueueFullPolicy(QueueFullReject))
+	ret := executor.JoinHedged(10*time.Millisecond, 1, errorFunctionAfter50Ms)
+	assert.ErrorIs(t, ret.Error(), ErrQueueFull)
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	causes := make(chan error, 1)
+	ret = NewExecutor(2).JoinHedgedContext(context.Background(), 10*time.Millisecond, 1, slowFirstCallFunction(causes))
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	assert.ErrorIs(t, <-causes, ErrSuccessFound)
+}
+
+func Test_GivenRateLimitedExecutor_WhenFirstCallSucceedWhileHedgeWait_ThenDoNotStartHedge(t *testing.T) {
+	var calls int32
+	executor := NewExecutor(2, WithRateLimiter(NewTokenBucket(10, 1)))
+	start := time.Now()
+	ret := executor.JoinHedged(10*time.Millisecond, 1, func() Return {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(30 * time.Millisecond)
+		return NewReturn(nil, successValue)
+	})
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	assert.Less(t, time.Since(start), 80*time.Millisecond, "join must not wait the hedge submit")
+	// the next token is available after 100ms
+	time.Sleep(120 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+// AdaptiveHedger tests
+
+func Test_GivenNoObservedLatency_WhenDelay_ThenReturnInitialDelay(t *testing.T) {
+	hedger := NewAdaptiveHedger(0.9, 0, time.Second, 1)
+	assert.Equal(t, time.Second, hedger.Delay())
+}
+
+func Test_GivenObservedLatencies_WhenDelay_ThenReturnPercentileOfWindow(t *testing.T) {
+	hedger := NewAdaptiveHedger(0.5, 4, time.Second, 1)
+	for _, latency := range []time.Duration{40, 10, 30, 20} {
+		hedger.Observe(latency * time.Millisecond)
+	}
+	assert.Equal(t, 20*time.Millisecond, hedger.Delay())
+	hedger.Observe(50 * time.Millisecond)
+	hedger.Observe(60 * time.Millisecond)
+	assert.Equal(t, 30*time.Millisecond, hedger.Delay(), "oldest latencies must leave the window")
+	assert.Equal(t, 20*time.Millisecond, observedHedger(0, 20*time.Millisecond).Delay())
+	assert.Equal(t, 20*time.Millisecond, observedHedger(2, 20*time.Millisecond).Delay())
+}
+
+// observedHedger return an AdaptiveHedger with a window of one observed latency
+func observedHedger(percentile float64, latency time.Duration) *AdaptiveHedger {
+	hedger := NewAdaptiveHedger(percentile, 1, time.Second, 1)
+	hedger.Observe(latency)
+	return hedger
+}
+
+func Test_GivenAdaptiveHedger_WhenJoinHedged_ThenObserveLatencyOfSuccess(t *testing.T) {
+	hedger := NewAdaptiveHedger(1, 10, time.Second, 1)
+	ret := hedger.JoinHedged(successFunction)
+	assert.Nil(t, ret.Error())
+	assert.Less(t, hedger.Delay(), time.Second)
+	hedger = NewAdaptiveHedger(1, 10, time.Second, 1)
+	ret = hedger.JoinHedged(errorFunction)
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	assert.Equal(t, time.Second, hedger.Delay(), "failures must not be observed")
+}
+
+func Test_GivenSlowHedgedCalls_WhenJoinHedged_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinHedged(10*time.Millisecond, 3, successFunctionAfter50Ms)
+	})
+}
@@ -0,0 +1,96 @@
+package gauss
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertNoGoroutineLeak run join and assert the number of goroutines return to the baseline
+// once every function passed to the join finished
+func assertNoGoroutineLeak(t *testing.T, join func()) {
+	t.Helper()
+	baseline := runtime.NumGoroutine()
+	join()
+	// polling in the test goroutine, assert.Eventually spawn its own goroutines
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline, "goroutines must return to baseline")
+}
+
+func errorFunctionAfter50Ms() Return {
+	time.Sleep(50 * time.Millisecond)
+	return NewReturn(errNormal)
+}
+
+func successFunctionAfter50Ms() Return {
+	time.Sleep(50 * time.Millisecond)
+	return NewReturn(nil, successValue)
+}
+
+func panicFunctionAfter50Ms() Return {
+	time.Sleep(50 * time.Millisecond)
+	panic("panic")
+}
+
+func Test_GivenLateFailingFunctions_WhenJoinFailOnAnyError_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinFailOnAnyError(errorFunction, errorFunctionAfter50Ms, errorFunctionAfter50Ms, panicFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenLateFailingFunctions_WhenJoinFailOnAnyErrorSuccessFailFunction_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinFailOnAnyErrorSuccessFailFunction(func(returns []Return) {}, func(returns []Return, err error) {},
+			errorFunction, errorFunctionAfter50Ms, panicFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenMixedFunctions_WhenJoinCompleteAll_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinCompleteAll(successFunction, errorFunctionAfter50Ms, panicFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenLateSucceedingFunctions_WhenJoinCompleteOnAnySuccess_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinCompleteOnAnySuccess(successFunction, successFunctionAfter50Ms, successFunctionAfter50Ms, panicFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenLateSucceedingFunctions_WhenJoinCompleteOnAnySuccessSuccessFailFunction_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinCompleteOnAnySuccessSuccessFailFunction(func(returns []Return) {}, func(returns []Return, err error) {},
+			successFunction, successFunctionAfter50Ms, successFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenSlowFunctions_WhenJoinFailOnErrorOrTimeout_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinFailOnErrorOrTimeout(10*time.Millisecond, errorFunctionAfter50Ms, successFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenSlowFunctions_WhenJoinFailOnErrorOrTimeoutSuccessFailFunction_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinFailOnErrorOrTimeoutSuccessFailFunction(func(returns []Return) {}, func(returns []Return, err error) {},
+			10*time.Millisecond, errorFunctionAfter50Ms, panicFunctionAfter50Ms)
+	})
+}
+
+func Test_GivenLateFailingFunctions_WhenJoinFailOnAnyErrorContext_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinFailOnAnyErrorContext(context.Background(), errorContextFunction, contextFunction(errorFunctionAfter50Ms))
+	})
+}
+
+func Test_GivenLateSucceedingFunctions_WhenJoinCompleteOnAnySuccessContext_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		JoinCompleteOnAnySuccessContext(context.Background(), successContextFunction, contextFunction(successFunctionAfter50Ms))
+	})
+}
@@ -0,0 +1,164 @@
+package gauss
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type parallelOptions struct {
+	concurrency int
+	chunkSize   int
+	collectAll  bool
+}
+
+type ParallelOption func(options *parallelOptions)
+
+// WithConcurrency set the number of goroutines processing items, default runtime.GOMAXPROCS(0)
+func WithConcurrency(concurrency int) ParallelOption {
+	return func(options *parallelOptions) {
+		options.concurrency = concurrency
+	}
+}
+
+// WithChunkSize set the number of consecutive items taken by a goroutine at once, default 1
+func WithChunkSize(chunkSize int) ParallelOption {
+	return func(options *parallelOptions) {
+		options.chunkSize = chunkSize
+	}
+}
+
+// WithCollectAll process every item even when some fail and return a *MultiError with the error of each
+// failed item, by default the first error cancel the remaining items and is returned
+func WithCollectAll() ParallelOption {
+	return func(options *parallelOptions) {
+		options.collectAll = true
+	}
+}
+
+func newParallelOptions(options []ParallelOption) *parallelOptions {
+	parallel := &parallelOptions{concurrency: runtime.GOMAXPROCS(0), chunkSize: 1}
+	for _, option := range options {
+		option(parallel)
+	}
+	if parallel.concurrency < 1 {
+		parallel.concurrency = 1
+	}
+	if parallel.chunkSize < 1 {
+		parallel.chunkSize = 1
+	}
+	return parallel
+}
+
+// parallelFor call function with the index of each item from a bounded number of goroutines that take chunks
+// of consecutive indexes, a panic is returned as a *PanicError with the index of the item
+func parallelFor(parent context.Context, count int, options []ParallelOption, function func(ctx context.Context, index int) error) error {
+	if parent.Err() != nil {
+		return context.Cause(parent)
+	}
+	parallel := newParallelOptions(options)
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	chunks := (count + parallel.chunkSize - 1) / parallel.chunkSize
+	workers := parallel.concurrency
+	if workers > chunks {
+		workers = chunks
+	}
+	returns := make([]Return, count)
+	var nextChunk int64
+	var group sync.WaitGroup
+	group.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer group.Done()
+			for chunk := int(atomic.AddInt64(&nextChunk, 1) - 1); chunk < chunks; chunk = int(atomic.AddInt64(&nextChunk, 1) - 1) {
+				end := (chunk + 1) * parallel.chunkSize
+				if end > count {
+					end = count
+				}
+				for index := chunk * parallel.chunkSize; index < end; index++ {
+					if ctx.Err() != nil {
+						return
+					}
+					index := index
+					ret := runFunction(ctx, index, func(ctx context.Context) Return {
+						return NewReturn(function(ctx, index))
+					})
+					returns[index] = ret
+					if err := ret.Error(); err != nil && !parallel.collectAll {
+						cancel(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	group.Wait()
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return ErrorOf(returns)
+}
+
+// ParallelMap call function with each item from a bounded number of goroutines and return the values in the
+// order of items. Return the first error, or with WithCollectAll a *MultiError with the error of each failed
+// item whose value is the zero value
+func ParallelMap[T any, U any](ctx context.Context, items []T, function func(ctx context.Context, item T) (U, error), options ...ParallelOption) ([]U, error) {
+	values := make([]U, len(items))
+	err := parallelFor(ctx, len(items), options, func(ctx context.Context, index int) error {
+		value, err := function(ctx, items[index])
+		if err == nil {
+			values[index] = value
+		}
+		return err
+	})
+	return values, err
+}
+
+// ParallelForEach call function with each item from a bounded number of goroutines
+func ParallelForEach[T any](ctx context.Context, items []T, function func(ctx context.Context, item T) error, options ...ParallelOption) error {
+	return parallelFor(ctx, len(items), options, func(ctx context.Context, index int) error {
+		return function(ctx, items[index])
+	})
+}
+
+// ParallelFilter return the items for which predicate return true, in the order of items
+func ParallelFilter[T any](ctx context.Context, items []T, predicate func(ctx context.Context, item T) (bool, error), options ...ParallelOption) ([]T, error) {
+	keep, err := ParallelMap(ctx, items, predicate, options...)
+	var filtered []T
+	for index, item := range items {
+		if keep[index] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, err
+}
+
+// ParallelReduce combine the items in a tree, each level combine pairs of adjacent items in parallel so
+// function must be associative. Return the zero value when items is empty and the first item when it has one.
+// With WithCollectAll a level is completed before returning the *MultiError of its failed pairs
+func ParallelReduce[T any](ctx context.Context, items []T, function func(ctx context.Context, left T, right T) (T, error), options ...ParallelOption) (T, error) {
+	var zero T
+	level := items
+	for len(level) > 1 {
+		current, next := level, make([]T, (len(level)+1)/2)
+		err := parallelFor(ctx, len(next), options, func(ctx context.Context, index int) error {
+			if 2*index+1 == len(current) {
+				next[index] = current[2*index]
+				return nil
+			}
+			value, err := function(ctx, current[2*index], current[2*index+1])
+			next[index] = value
+			return err
+		})
+		if err != nil {
+			return zero, err
+		}
+		level = next
+	}
+	if len(level) == 0 {
+		return zero, nil
+	}
+	return level[0], nil
+}
@@ -0,0 +1,132 @@
+package gauss
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func numbers(count int) []int {
+	items := make([]int, count)
+	for index := range items {
+		items[index] = index
+	}
+	return items
+}
+
+func Test_GivenItems_WhenParallelMap_ThenReturnValuesInOrder(t *testing.T) {
+	values, err := ParallelMap(context.Background(), numbers(100), func(ctx context.Context, item int) (string, error) {
+		time.Sleep(time.Duration(100-item) * time.Microsecond)
+		return strconv.Itoa(item), nil
+	}, WithConcurrency(8), WithChunkSize(3))
+	assert.Nil(t, err)
+	assert.Len(t, values, 100)
+	for index, value := range values {
+		assert.Equal(t, strconv.Itoa(index), value)
+	}
+}
+
+func Test_GivenConcurrencyLimit_WhenParallelForEach_ThenRunAtMostLimitItemsAtOnce(t *testing.T) {
+	var running, maxRunning int32
+	err := ParallelForEach(context.Background(), numbers(20), func(ctx context.Context, item int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}, WithConcurrency(3), WithChunkSize(0))
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+}
+
+func Test_GivenFailingItem_WhenParallelForEachFailFast_ThenStopAndReturnError(t *testing.T) {
+	var calls int32
+	err := ParallelForEach(context.Background(), numbers(100), func(ctx context.Context, item int) error {
+		atomic.AddInt32(&calls, 1)
+		if item == 0 {
+			return errNormal
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	}, WithConcurrency(4))
+	assert.ErrorIs(t, err, errNormal)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(100))
+}
+
+func Test_GivenFailingItems_WhenParallelMapCollectAll_ThenReturnMultiErrorAndOtherValues(t *testing.T) {
+	values, err := ParallelMap(context.Background(), numbers(10), func(ctx context.Context, item int) (int, error) {
+		if item%3 == 0 {
+			return -1, errNormal
+		}
+		return item * 2, nil
+	}, WithCollectAll())
+	var multiError *MultiError
+	assert.ErrorAs(t, err, &multiError)
+	assert.Len(t, multiError.Errors, 4)
+	assert.Equal(t, 3, multiError.Errors[1].Index)
+	assert.Equal(t, []int{0, 2, 4, 0, 8, 10, 0, 14, 16, 0}, values)
+}
+
+func Test_GivenPanicItem_WhenParallelMap_ThenReturnPanicErrorWithItemIndex(t *testing.T) {
+	_, err := ParallelMap(context.Background(), numbers(3), func(ctx context.Context, item int) (int, error) {
+		if item == 2 {
+			panic("panic")
+		}
+		return item, nil
+	})
+	var panicError *PanicError
+	assert.ErrorAs(t, err, &panicError)
+	assert.Equal(t, 2, panicError.Index)
+}
+
+func Test_GivenCancelledContext_WhenParallelMap_ThenReturnCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ParallelMap(ctx, numbers(3), func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_GivenItems_WhenParallelFilter_ThenReturnMatchingItemsInOrder(t *testing.T) {
+	even, err := ParallelFilter(context.Background(), numbers(10), func(ctx context.Context, item int) (bool, error) {
+		return item%2 == 0, nil
+	}, WithConcurrency(0))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, even)
+}
+
+func Test_GivenItems_WhenParallelReduce_ThenCombineItemsInOrder(t *testing.T) {
+	concat := func(ctx context.Context, left string, right string) (string, error) {
+		return left + right, nil
+	}
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+	result, err := ParallelReduce(context.Background(), items, concat, WithConcurrency(4))
+	assert.Nil(t, err)
+	assert.Equal(t, "abcdefg", result)
+	result, err = ParallelReduce(context.Background(), []string{}, concat)
+	assert.Nil(t, err)
+	assert.Equal(t, "", result)
+	result, _ = ParallelReduce(context.Background(), []string{"a"}, concat)
+	assert.Equal(t, "a", result)
+}
+
+func Test_GivenFailingCombination_WhenParallelReduce_ThenReturnError(t *testing.T) {
+	result, err := ParallelReduce(context.Background(), numbers(8), func(ctx context.Context, left int, right int) (int, error) {
+		if left+right > 10 {
+			return 0, errNormal
+		}
+		return left + right, nil
+	})
+	assert.ErrorIs(t, err, errNormal)
+	assert.Equal(t, 0, result)
+}
@@ -0,0 +1,257 @@
+package gauss
+
+import (
+	"context"
+	"sync"
+)
+
+// SourceFunction produce the items of a Pipeline calling emit, emit return false when the pipeline is shut
+// down and the source must return
+type SourceFunction func(ctx context.Context, emit func(item interface{}) bool) error
+
+// StageFunction transform an item of a Pipeline
+type StageFunction func(ctx context.Context, item interface{}) (interface{}, error)
+
+// SinkFunction consume the items of a Pipeline
+type SinkFunction func(ctx context.Context, item interface{}) error
+
+// Pipeline connect a source, transform stages and a sink through bounded channels, a stage that does not
+// consume its input block the previous stages
+type Pipeline struct {
+	source     SourceFunction
+	stages     []*pipelineStage
+	bufferSize int
+}
+
+type pipelineStage struct {
+	function StageFunction
+	workers  int
+	ordered  bool
+}
+
+type PipelineOption func(pipeline *Pipeline)
+
+// WithBufferSize set the capacity of the channels between stages, default 0
+func WithBufferSize(bufferSize int) PipelineOption {
+	return func(pipeline *Pipeline) {
+		pipeline.bufferSize = bufferSize
+	}
+}
+
+type StageOption func(stage *pipelineStage)
+
+// WithWorkers set the number of goroutines running the stage function, default 1
+func WithWorkers(workers int) StageOption {
+	return func(stage *pipelineStage) {
+		stage.workers = workers
+	}
+}
+
+// WithOrderedOutput emit the items of the stage in the order they were received, by default items are
+// emitted as soon as a worker transform them
+func WithOrderedOutput() StageOption {
+	return func(stage *pipelineStage) {
+		stage.ordered = true
+	}
+}
+
+// NewPipeline create a Pipeline whose items are produced by source
+func NewPipeline(source SourceFunction, options ...PipelineOption) *Pipeline {
+	pipeline := &Pipeline{source: source}
+	for _, option := range options {
+		option(pipeline)
+	}
+	if pipeline.bufferSize < 0 {
+		pipeline.bufferSize = 0
+	}
+	return pipeline
+}
+
+// Stage add a stage that transform each item with function
+func (_self *Pipeline) Stage(function StageFunction, options ...StageOption) *Pipeline {
+	stage := &pipelineStage{function: function, workers: 1}
+	for _, option := range options {
+		option(stage)
+	}
+	if stage.workers < 1 {
+		stage.workers = 1
+	}
+	_self.stages = append(_self.stages, stage)
+	return _self
+}
+
+// pipelineRun state of a running Pipeline, every goroutine exit when its input is closed or ctx is done
+type pipelineRun struct {
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	group      sync.WaitGroup
+	bufferSize int
+}
+
+// pipelineJob item of an ordered stage, the worker send the Return to result
+type pipelineJob struct {
+	item   interface{}
+	result chan Return
+}
+
+// call run function with runFunction so a panic is a *PanicError with the index of the stage, shut down the
+// pipeline when function fail
+func (_self *pipelineRun) call(index int, function func(ctx context.Context) (interface{}, error)) (Return, bool) {
+	ret := runFunction(_self.ctx, index, func(ctx context.Context) Return {
+		value, err := function(ctx)
+		return NewReturn(err, value)
+	})
+	if err := ret.Error(); err != nil {
+		_self.cancel(err)
+		return ret, false
+	}
+	return ret, true
+}
+
+// send send item to out, return false when the pipeline is shut down
+func (_self *pipelineRun) send(out chan<- interface{}, item interface{}) bool {
+	select {
+	case out <- item:
+		return true
+	case <-_self.ctx.Done():
+		return false
+	}
+}
+
+// receive receive an item from in, return false when in is closed or the pipeline is shut down
+func (_self *pipelineRun) receive(in <-chan interface{}) (interface{}, bool) {
+	select {
+	case item, ok := <-in:
+		return item, ok
+	case <-_self.ctx.Done():
+		return nil, false
+	}
+}
+
+func (_self *pipelineRun) goroutine(task func()) {
+	_self.group.Add(1)
+	go func() {
+		defer _self.group.Done()
+		task()
+	}()
+}
+
+func (_self *pipelineRun) runSource(source SourceFunction) <-chan interface{} {
+	out := make(chan interface{}, _self.bufferSize)
+	_self.goroutine(func() {
+		defer close(out)
+		_self.call(0, func(ctx context.Context) (interface{}, error) {
+			return nil, source(ctx, func(item interface{}) bool {
+				return _self.send(out, item)
+			})
+		})
+	})
+	return out
+}
+
+func (_self *pipelineRun) runStage(index int, stage *pipelineStage, in <-chan interface{}) <-chan interface{} {
+	if stage.ordered {
+		return _self.runOrderedStage(index, stage, in)
+	}
+	out := make(chan interface{}, _self.bufferSize)
+	var workers sync.WaitGroup
+	workers.Add(stage.workers)
+	for worker := 0; worker < stage.workers; worker++ {
+		_self.goroutine(func() {
+			defer workers.Done()
+			for item, ok := _self.receive(in); ok; item, ok = _self.receive(in) {
+				ret, success := _self.transform(index, stage.function, item)
+				if !success || !_self.send(out, ret.ReturnValues()[0]) {
+					return
+				}
+			}
+		})
+	}
+	_self.goroutine(func() {
+		workers.Wait()
+		close(out)
+	})
+	return out
+}
+
+// runOrderedStage a dispatcher queue the result channel of each item in reception order and a collector
+// emit the results in that order, at most workers items are in flight so backpressure is kept
+func (_self *pipelineRun) runOrderedStage(index int, stage *pipelineStage, in <-chan interface{}) <-chan interface{} {
+	out := make(chan interface{}, _self.bufferSize)
+	jobs := make(chan pipelineJob)
+	results := make(chan chan Return, stage.workers)
+	_self.goroutine(func() {
+		defer close(jobs)
+		defer close(results)
+		for item, ok := _self.receive(in); ok; item, ok = _self.receive(in) {
+			job := pipelineJob{item: item, result: make(chan Return, 1)}
+			select {
+			case results <- job.result:
+			case <-_self.ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-_self.ctx.Done():
+				return
+			}
+		}
+	})
+	for worker := 0; worker < stage.workers; worker++ {
+		_self.goroutine(func() {
+			for job := range jobs {
+				ret, _ := _self.transform(index, stage.function, job.item)
+				job.result <- ret
+			}
+		})
+	}
+	_self.goroutine(func() {
+		defer close(out)
+		for result := range results {
+			select {
+			case ret := <-result:
+				if ret.Error() != nil || !_self.send(out, ret.ReturnValues()[0]) {
+					return
+				}
+			case <-_self.ctx.Done():
+				return
+			}
+		}
+	})
+	return out
+}
+
+func (_self *pipelineRun) transform(index int, function StageFunction, item interface{}) (Return, bool) {
+	return _self.call(index, func(ctx context.Context) (interface{}, error) {
+		return function(ctx, item)
+	})
+}
+
+// Run run the pipeline and send each item of the last stage to sink in the caller goroutine. Return when the
+// source and every item are consumed, or with the first error of the source, a stage or the sink, a panic is
+// returned as a *PanicError whose index is the position of the stage, source is 0 and sink is the last. The
+// context received by stages is cancelled with the error as cause
+func (_self *Pipeline) Run(parent context.Context, sink SinkFunction) error {
+	if parent.Err() != nil {
+		return context.Cause(parent)
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	run := &pipelineRun{ctx: ctx, cancel: cancel, bufferSize: _self.bufferSize}
+	in := run.runSource(_self.source)
+	for index, stage := range _self.stages {
+		in = run.runStage(index+1, stage, in)
+	}
+	for item, ok := run.receive(in); ok; item, ok = run.receive(in) {
+		if _, success := run.call(len(_self.stages)+1, func(ctx context.Context) (interface{}, error) {
+			return nil, sink(ctx, item)
+		}); !success {
+			break
+		}
+	}
+	run.group.Wait()
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return nil
+}
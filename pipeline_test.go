@@ -0,0 +1,163 @@
+package gauss
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rangeSource return a source that emit the integers from 0 to count-1
+func rangeSource(count int) SourceFunction {
+	return func(ctx context.Context, emit func(item interface{}) bool) error {
+		for item := 0; item < count; item++ {
+			if !emit(item) {
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+}
+
+// slowerFirstItemsStage multiply each item by 10, first items take longer so workers complete out of order
+func slowerFirstItemsStage(ctx context.Context, item interface{}) (interface{}, error) {
+	time.Sleep(time.Duration(10-item.(int)) * time.Millisecond)
+	return item.(int) * 10, nil
+}
+
+// collectSink return a sink that append each item to items
+func collectSink(items *[]int) SinkFunction {
+	return func(ctx context.Context, item interface{}) error {
+		*items = append(*items, item.(int))
+		return nil
+	}
+}
+
+func Test_GivenOrderedStage_WhenRun_ThenSinkReceiveItemsInSourceOrder(t *testing.T) {
+	var items []int
+	err := NewPipeline(rangeSource(10), WithBufferSize(2)).
+		Stage(slowerFirstItemsStage, WithWorkers(4), WithOrderedOutput()).
+		Run(context.Background(), collectSink(&items))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, items)
+}
+
+func Test_GivenUnorderedStages_WhenRun_ThenSinkReceiveEveryItem(t *testing.T) {
+	var items []int
+	err := NewPipeline(rangeSource(10), WithBufferSize(-1)).
+		Stage(slowerFirstItemsStage, WithWorkers(4)).
+		Stage(func(ctx context.Context, item interface{}) (interface{}, error) {
+			return item.(int) + 1, nil
+		}, WithWorkers(0)).
+		Run(context.Background(), collectSink(&items))
+	assert.Nil(t, err)
+	sort.Ints(items)
+	assert.Equal(t, []int{1, 11, 21, 31, 41, 51, 61, 71, 81, 91}, items)
+}
+
+func Test_GivenSlowSink_WhenRun_ThenSourceIsBlockedByBoundedChannels(t *testing.T) {
+	var emitted int32
+	source := func(ctx context.Context, emit func(item interface{}) bool) error {
+		for item := 0; item < 100; item++ {
+			if !emit(item) {
+				return ctx.Err()
+			}
+			atomic.AddInt32(&emitted, 1)
+		}
+		return nil
+	}
+	var maxEmitted int32
+	err := NewPipeline(source, WithBufferSize(1)).
+		Stage(slowerFirstItemsStage, WithWorkers(2), WithOrderedOutput()).
+		Run(context.Background(), func(ctx context.Context, item interface{}) error {
+			if item.(int) == 0 {
+				time.Sleep(50 * time.Millisecond)
+				maxEmitted = atomic.LoadInt32(&emitted)
+			}
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, maxEmitted, int32(10), "bounded channels must block the source")
+}
+
+func Test_GivenFailingStage_WhenRun_ThenShutDownPipelineAndReturnError(t *testing.T) {
+	sourceErrors := make(chan error, 1)
+	source := func(ctx context.Context, emit func(item interface{}) bool) error {
+		for item := 0; ; item++ {
+			if !emit(item) {
+				sourceErrors <- context.Cause(ctx)
+				return ctx.Err()
+			}
+		}
+	}
+	for _, option := range []StageOption{WithWorkers(2), WithOrderedOutput()} {
+		err := NewPipeline(source).
+			Stage(func(ctx context.Context, item interface{}) (interface{}, error) {
+				if item.(int) == 5 {
+					return nil, errNormal
+				}
+				return item, nil
+			}, option).
+			Run(context.Background(), func(ctx context.Context, item interface{}) error {
+				return nil
+			})
+		assert.ErrorIs(t, err, errNormal)
+		assert.ErrorIs(t, <-sourceErrors, errNormal)
+	}
+}
+
+func Test_GivenPanicStage_WhenRun_ThenReturnPanicErrorWithStageIndex(t *testing.T) {
+	for _, option := range []StageOption{WithWorkers(2), WithOrderedOutput()} {
+		err := NewPipeline(rangeSource(10)).
+			Stage(func(ctx context.Context, item interface{}) (interface{}, error) {
+				panic("panic")
+			}, option).
+			Run(context.Background(), func(ctx context.Context, item interface{}) error {
+				return nil
+			})
+		var panicError *PanicError
+		assert.ErrorAs(t, err, &panicError)
+		assert.Equal(t, 1, panicError.Index)
+	}
+}
+
+func Test_GivenFailingSourceOrSink_WhenRun_ThenReturnError(t *testing.T) {
+	err := NewPipeline(func(ctx context.Context, emit func(item interface{}) bool) error {
+		return errNormal
+	}).Run(context.Background(), collectSink(&[]int{}))
+	assert.ErrorIs(t, err, errNormal)
+	err = NewPipeline(rangeSource(10)).
+		Stage(slowerFirstItemsStage, WithOrderedOutput()).
+		Run(context.Background(), func(ctx context.Context, item interface{}) error {
+			time.Sleep(20 * time.Millisecond)
+			return errNormal
+		})
+	assert.ErrorIs(t, err, errNormal)
+}
+
+func Test_GivenContextCancelled_WhenRun_ThenReturnCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := NewPipeline(rangeSource(1000)).
+		Stage(slowerFirstItemsStage, WithOrderedOutput()).
+		Run(ctx, func(ctx context.Context, item interface{}) error {
+			cancel()
+			return nil
+		})
+	assert.ErrorIs(t, err, context.Canceled)
+	err = NewPipeline(rangeSource(10)).Run(ctx, collectSink(&[]int{}))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_GivenFailingPipeline_WhenRun_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		NewPipeline(rangeSource(100), WithBufferSize(4)).
+			Stage(slowerFirstItemsStage, WithWorkers(4)).
+			Stage(slowerFirstItemsStage, WithWorkers(4), WithOrderedOutput()).
+			Run(context.Background(), func(ctx context.Context, item interface{}) error {
+				return errNormal
+			})
+	})
+}
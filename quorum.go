@@ -0,0 +1,91 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrQuorumNotReached error returned by JoinQuorum when too many functions fail to reach the quorum
+	ErrQuorumNotReached = errors.New("quorum not reached")
+	// ErrInvalidQuorum error returned by JoinQuorum when quorum is less than 1
+	ErrInvalidQuorum = errors.New("invalid quorum")
+)
+
+// joinQuorum wait until quorum functions success, success become impossible or parent context done. The context
+// received by functions is cancelled with ErrSuccessFound as cause when quorum is reached or with the error otherwise.
+// No function is called when quorum is less than 1 or greater than the number of functions
+func joinQuorum(parent context.Context, submit submitFunction, quorum int, funcs []ContextFunction) ([]Return, error) {
+	if quorum < 1 {
+		return fillIncomplete(make([]Return, len(funcs)), StatusPending), fmt.Errorf("%w: %d", ErrInvalidQuorum, quorum)
+	}
+	if quorum > len(funcs) {
+		err := fmt.Errorf("%w: quorum %d greater than %d functions", ErrQuorumNotReached, quorum, len(funcs))
+		return fillIncomplete(make([]Return, len(funcs)), StatusPending), err
+	}
+	if parent.Err() != nil {
+		cause := context.Cause(parent)
+		return doneReturns(len(funcs), cause), cause
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	returns := make([]Return, len(funcs))
+	completions := startFunctions(ctx, submit, funcs)
+	var err error
+	status := StatusPending
+	successes, failures := 0, 0
+	for successes < quorum && err == nil {
+		if len(funcs)-failures < quorum {
+			err = fmt.Errorf("%w: %d of %d functions fail", ErrQuorumNotReached, failures, len(funcs))
+			break
+		}
+		select {
+		case c := <-completions:
+			returns[c.index] = c.ret
+			if c.ret.Error() == nil {
+				successes++
+			} else {
+				failures++
+			}
+		case <-ctx.Done():
+			err = context.Cause(ctx)
+			status = statusOfCause(err)
+		}
+	}
+	if err == nil {
+		cancel(ErrSuccessFound)
+	} else {
+		cancel(err)
+	}
+	return fillIncomplete(returns, status), err
+}
+
+// JoinQuorum Run functions and return when quorum functions success, or fail with ErrQuorumNotReached as soon
+// as more than len(funcs)-quorum functions fail. Return ErrInvalidQuorum when quorum is less than 1
+func JoinQuorum(quorum int, funcs ...Function) ([]Return, error) {
+	return joinQuorum(context.Background(), spawnGoroutine, quorum, contextFunctions(funcs))
+}
+
+// JoinQuorumSuccessFailFunction Run functions and call successFunction when quorum functions success or call
+// failFunction when the quorum can not be reached
+func JoinQuorumSuccessFailFunction(successFunction SuccessFunction, failFunction FailFunction, quorum int, funcs ...Function) {
+	returns, err := JoinQuorum(quorum, funcs...)
+	callSuccessFailFunction(successFunction, failFunction, returns, err)
+}
+
+// JoinQuorumContext Run functions and return when quorum functions success or the quorum can not be reached,
+// the context received by functions is cancelled with ErrSuccessFound as cause when quorum is reached or with
+// the error otherwise
+func JoinQuorumContext(ctx context.Context, quorum int, funcs ...ContextFunction) ([]Return, error) {
+	return joinQuorum(ctx, spawnGoroutine, quorum, funcs)
+}
+
+// JoinQuorum JoinQuorum running functions with the executor
+func (_self *Executor) JoinQuorum(quorum int, funcs ...Function) ([]Return, error) {
+	return joinQuorum(context.Background(), _self.submit, quorum, contextFunctions(funcs))
+}
+
+// JoinQuorumContext JoinQuorumContext running functions with the executor
+func (_self *Executor) JoinQuorumContext(ctx context.Context, quorum int, funcs ...ContextFunction) ([]Return, error) {
+	return joinQuorum(ctx, _self.submit, quorum, funcs)
+}
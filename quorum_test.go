@@ -0,0 +1,102 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenTwoSuccessFunctions_WhenJoinQuorum2_ThenReturnNilErrorWithoutWaitingOthers(t *testing.T) {
+	returns, err := JoinQuorum(2, successFunction, errorFunction, successFunction, successFunctionAfter50Ms)
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, StatusOf(returns[3]))
+}
+
+func Test_GivenTooManyFailures_WhenJoinQuorum_ThenReturnErrQuorumNotReachedWithoutWaitingOthers(t *testing.T) {
+	returns, err := JoinQuorum(2, errorFunction, errorFunction, successFunctionAfter50Ms)
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+	assert.Equal(t, StatusPending, StatusOf(returns[2]))
+}
+
+func Test_GivenQuorumGreaterThanFunctions_WhenJoinQuorum_ThenReturnErrQuorumNotReached(t *testing.T) {
+	returns, err := JoinQuorum(3, successFunction, func() Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+	assert.Equal(t, StatusPending, StatusOf(returns[0]))
+}
+
+func Test_GivenQuorumLessThanOne_WhenJoinQuorum_ThenReturnErrInvalidQuorum(t *testing.T) {
+	for _, quorum := range []int{0, -1} {
+		returns, err := JoinQuorum(quorum, func() Return {
+			t.Error("function must not be called")
+			return NewReturn(nil)
+		})
+		assert.ErrorIs(t, err, ErrInvalidQuorum)
+		assert.Equal(t, StatusPending, StatusOf(returns[0]))
+	}
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinQuorum_ThenPanicCountAsFailure(t *testing.T) {
+	_, err := JoinQuorum(1, panicFunction)
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+}
+
+func Test_GivenQuorumReached_WhenJoinQuorumContext_ThenCancelOthersWithSuccessFoundCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	_, err := JoinQuorumContext(context.Background(), 1, causeContextFunction(started, causes), afterStarted(started, successContextFunction))
+	assert.Nil(t, err)
+	assert.ErrorIs(t, <-causes, ErrSuccessFound)
+}
+
+func Test_GivenQuorumNotReached_WhenJoinQuorumContext_ThenCancelOthersWithErrorCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	_, err := JoinQuorumContext(context.Background(), 2, causeContextFunction(started, causes),
+		afterStarted(started, errorContextFunction), afterStarted(started, errorContextFunction))
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+	assert.ErrorIs(t, <-causes, ErrQuorumNotReached)
+}
+
+func Test_GivenCancelledContext_WhenJoinQuorumContext_ThenReturnCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	returns, err := JoinQuorumContext(ctx, 1, successContextFunction)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+}
+
+func Test_GivenContextCancelledWhileRunning_WhenJoinQuorumContext_ThenReturnCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	returns, err := JoinQuorumContext(ctx, 1, func(ctx context.Context) Return {
+		cancel()
+		return successFunctionAfter50Ms()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+}
+
+func Test_GivenQuorumReached_WhenJoinQuorumSuccessFailFunction_ThenCallSuccessFunction(t *testing.T) {
+	JoinQuorumSuccessFailFunction(func(returns []Return) {
+		assert.Equal(t, successValue, returns[0].ReturnValues()[0])
+	}, func(returns []Return, err error) {
+		assert.True(t, false, "JoinQuorumSuccessFailFunction must no call fail function")
+	}, 1, successFunction)
+}
+
+func Test_GivenQuorumNotReached_WhenJoinQuorumSuccessFailFunction_ThenCallFailFunction(t *testing.T) {
+	JoinQuorumSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "JoinQuorumSuccessFailFunction must no call success function")
+	}, func(returns []Return, err error) {
+		assert.ErrorIs(t, err, ErrQuorumNotReached)
+	}, 1, errorFunction)
+}
+
+func Test_GivenExecutor_WhenJoinQuorum_ThenReturnNilError(t *testing.T) {
+	executor := NewExecutor(2)
+	_, err := executor.JoinQuorum(2, successFunction, successFunction, errorFunction)
+	assert.Nil(t, err)
+	_, err = executor.JoinQuorumContext(context.Background(), 1, errorContextFunction)
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+}
@@ -0,0 +1,290 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimitExceeded error returned by Wait when the Limiter can not reserve the event, e.g. a full
+	// LeakyBucket queue
+	ErrRateLimitExceeded = errors.New("rate limit exceeded")
+)
+
+// Limiter limit the rate of events, like function starts
+type Limiter interface {
+	// Wait block until an event is allowed or the context is done, in that case return the context cause
+	Wait(ctx context.Context) error
+	// Allow report whether an event is allowed now, an allowed event consume the limit
+	Allow() bool
+	// Reserve reserve an event and return when it is allowed, the reservation can be cancelled
+	Reserve() *Reservation
+}
+
+// Reservation event reserved in a Limiter
+type Reservation struct {
+	ok        bool
+	timeToAct time.Time
+	cancel    func()
+	once      sync.Once
+}
+
+// OK report whether the event can be allowed, false when the limiter can not reserve it
+func (_self *Reservation) OK() bool {
+	return _self.ok
+}
+
+// Delay return the time to wait before the event is allowed, zero if it is allowed now
+func (_self *Reservation) Delay() time.Duration {
+	if delay := time.Until(_self.timeToAct); delay > 0 {
+		return delay
+	}
+	return 0
+}
+
+// Cancel release the reservation when the event will not happen, so other events can use it
+func (_self *Reservation) Cancel() {
+	if !_self.ok || _self.Delay() == 0 {
+		return
+	}
+	_self.once.Do(_self.cancel)
+}
+
+// waitReservation wait until reservation is allowed or ctx is done, in that case cancel the reservation
+func waitReservation(ctx context.Context, reservation *Reservation) error {
+	if !reservation.OK() {
+		return ErrRateLimitExceeded
+	}
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return context.Cause(ctx)
+	}
+}
+
+// TokenBucket Limiter that allow rate events per second with bursts of at most burst events
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket create a full TokenBucket that allow rate events per second with bursts of at most burst
+// events, burst less than 1 is handled as 1
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// refill add the tokens produced since the last refill
+func (_self *TokenBucket) refill(now time.Time) {
+	_self.tokens += now.Sub(_self.last).Seconds() * _self.rate
+	if _self.tokens > _self.burst {
+		_self.tokens = _self.burst
+	}
+	_self.last = now
+}
+
+// Reserve take a token, the event is allowed once the bucket has produced the missing token
+func (_self *TokenBucket) Reserve() *Reservation {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	now := time.Now()
+	_self.refill(now)
+	if _self.tokens < 1 && _self.rate <= 0 {
+		return &Reservation{}
+	}
+	_self.tokens--
+	reservation := &Reservation{ok: true, timeToAct: now}
+	if _self.tokens < 0 {
+		reservation.timeToAct = now.Add(time.Duration(-_self.tokens / _self.rate * float64(time.Second)))
+	}
+	reservation.cancel = func() {
+		_self.mutex.Lock()
+		defer _self.mutex.Unlock()
+		// a reservation not allowed yet means the bucket is in debt, so the token never overflow the burst
+		_self.refill(time.Now())
+		_self.tokens++
+	}
+	return reservation
+}
+
+// Allow take a token if the bucket has one
+func (_self *TokenBucket) Allow() bool {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.refill(time.Now())
+	if _self.tokens < 1 {
+		return false
+	}
+	_self.tokens--
+	return true
+}
+
+// Wait take a token, waiting until the bucket has produced it
+func (_self *TokenBucket) Wait(ctx context.Context) error {
+	return waitReservation(ctx, _self.Reserve())
+}
+
+// LeakyBucket Limiter that allow events at a constant rate without bursts, at most capacity events can wait
+// their turn
+type LeakyBucket struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	capacity int
+	next     time.Time
+	// exhausted a bucket without rate allowed its single event
+	exhausted bool
+}
+
+// NewLeakyBucket create a LeakyBucket that allow rate events per second evenly spaced, at most capacity
+// reserved events wait their turn and further reservations are rejected, capacity less than 1 is unbounded.
+// Like a TokenBucket without rate, a rate less or equal than 0 allow a single event. Rates above one event
+// per nanosecond are handled as one event per nanosecond
+func NewLeakyBucket(rate float64, capacity int) *LeakyBucket {
+	bucket := &LeakyBucket{capacity: capacity}
+	if rate > 0 {
+		interval := float64(time.Second) / rate
+		switch {
+		case interval < 1:
+			bucket.interval = 1
+		case interval >= math.MaxInt64:
+			bucket.interval = math.MaxInt64
+		default:
+			bucket.interval = time.Duration(interval)
+		}
+	}
+	return bucket
+}
+
+// Reserve reserve the next free slot
+func (_self *LeakyBucket) Reserve() *Reservation {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	now := time.Now()
+	if _self.interval == 0 {
+		if _self.exhausted {
+			return &Reservation{}
+		}
+		_self.exhausted = true
+		return &Reservation{ok: true, timeToAct: now}
+	}
+	timeToAct := _self.next
+	if timeToAct.Before(now) {
+		timeToAct = now
+	}
+	// events waiting before timeToAct, including this one
+	delay := timeToAct.Sub(now)
+	waiting := delay / _self.interval
+	if delay%_self.interval != 0 {
+		waiting++
+	}
+	if _self.capacity > 0 && int64(waiting) > int64(_self.capacity) {
+		return &Reservation{}
+	}
+	_self.next = timeToAct.Add(_self.interval)
+	return &Reservation{ok: true, timeToAct: timeToAct, cancel: func() {
+		_self.mutex.Lock()
+		defer _self.mutex.Unlock()
+		// only the last slot can be released without moving the slots reserved after it
+		if _self.next.Equal(timeToAct.Add(_self.interval)) {
+			_self.next = timeToAct
+		}
+	}}
+}
+
+// Allow reserve the next slot if it is free now
+func (_self *LeakyBucket) Allow() bool {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	now := time.Now()
+	if _self.interval == 0 {
+		allowed := !_self.exhausted
+		_self.exhausted = true
+		return allowed
+	}
+	if _self.next.After(now) {
+		return false
+	}
+	_self.next = now.Add(_self.interval)
+	return true
+}
+
+// Wait reserve the next free slot and wait until it, return ErrRateLimitExceeded when the queue is full
+func (_self *LeakyBucket) Wait(ctx context.Context) error {
+	return waitReservation(ctx, _self.Reserve())
+}
+
+// KeyedLimiter Limiter per key, the Limiter of a key is created on first use
+type KeyedLimiter struct {
+	mutex      sync.Mutex
+	limiters   map[string]Limiter
+	newLimiter func() Limiter
+}
+
+// NewKeyedLimiter create a KeyedLimiter that create the Limiter of each key with newLimiter
+func NewKeyedLimiter(newLimiter func() Limiter) *KeyedLimiter {
+	return &KeyedLimiter{limiters: map[string]Limiter{}, newLimiter: newLimiter}
+}
+
+// Limiter return the Limiter of key
+func (_self *KeyedLimiter) Limiter(key string) Limiter {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	limiter, ok := _self.limiters[key]
+	if !ok {
+		limiter = _self.newLimiter()
+		_self.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// Wait Limiter.Wait of key
+func (_self *KeyedLimiter) Wait(ctx context.Context, key string) error {
+	return _self.Limiter(key).Wait(ctx)
+}
+
+// Allow Limiter.Allow of key
+func (_self *KeyedLimiter) Allow(key string) bool {
+	return _self.Limiter(key).Allow()
+}
+
+// Reserve Limiter.Reserve of key
+func (_self *KeyedLimiter) Reserve(key string) *Reservation {
+	return _self.Limiter(key).Reserve()
+}
+
+// RateLimitedContext return a ContextFunction that wait limiter before call function, if the wait fail the
+// Return contains its error and function is not called
+func RateLimitedContext(limiter Limiter, function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		if err := limiter.Wait(ctx); err != nil {
+			return NewReturn(err)
+		}
+		return function(ctx)
+	}
+}
+
+// RateLimited return a Function that wait limiter before call function, so functions of any join start at
+// the rate of limiter
+func RateLimited(limiter Limiter, function Function) Function {
+	limited := RateLimitedContext(limiter, contextFunction(function))
+	return func() Return {
+		return limited(context.Background())
+	}
+}
@@ -0,0 +1,158 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenTokenBucket_WhenAllow_ThenAllowBurstEvents(t *testing.T) {
+	limiter := NewTokenBucket(10, 2)
+	assert.True(t, limiter.Allow())
+	assert.True(t, limiter.Allow())
+	assert.False(t, limiter.Allow())
+	assert.False(t, NewTokenBucket(10, 0).Reserve().Delay() > 0, "burst less than 1 must allow one event")
+}
+
+func Test_GivenEmptyTokenBucket_WhenWait_ThenWaitNextToken(t *testing.T) {
+	limiter := NewTokenBucket(50, 1)
+	assert.Nil(t, limiter.Wait(context.Background()))
+	start := time.Now()
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+}
+
+func Test_GivenContextDone_WhenWait_ThenReturnCauseAndReleaseReservation(t *testing.T) {
+	limiter := NewTokenBucket(1, 1)
+	limiter.Allow()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+	assert.LessOrEqual(t, limiter.Reserve().Delay(), time.Second, "cancelled reservation must release its token")
+}
+
+func Test_GivenReservation_WhenCancel_ThenReleaseOnlyFutureReservation(t *testing.T) {
+	limiter := NewTokenBucket(1, 1)
+	now := limiter.Reserve()
+	assert.True(t, now.OK())
+	assert.Equal(t, time.Duration(0), now.Delay())
+	now.Cancel()
+	future := limiter.Reserve()
+	assert.Greater(t, future.Delay(), 900*time.Millisecond, "cancel of an allowed reservation must be ignored")
+	future.Cancel()
+	future.Cancel()
+	assert.Greater(t, limiter.Reserve().Delay(), 900*time.Millisecond)
+	assert.Greater(t, limiter.Reserve().Delay(), 1900*time.Millisecond, "cancel must release one token once")
+}
+
+func Test_GivenZeroRate_WhenTokensExhausted_ThenReservationIsNotOK(t *testing.T) {
+	limiter := NewTokenBucket(0, 1)
+	assert.True(t, limiter.Allow())
+	reservation := limiter.Reserve()
+	assert.False(t, reservation.OK())
+	reservation.Cancel()
+	assert.ErrorIs(t, limiter.Wait(context.Background()), ErrRateLimitExceeded)
+}
+
+func Test_GivenLeakyBucket_WhenReserve_ThenSpaceEventsEvenly(t *testing.T) {
+	limiter := NewLeakyBucket(100, 3)
+	assert.True(t, limiter.Allow())
+	assert.False(t, limiter.Allow())
+	second := limiter.Reserve()
+	assert.Greater(t, second.Delay(), 5*time.Millisecond)
+	assert.Greater(t, limiter.Reserve().Delay(), 15*time.Millisecond)
+	last := limiter.Reserve()
+	assert.True(t, last.OK())
+	assert.False(t, limiter.Reserve().OK(), "queue is full")
+	second.Cancel()
+	assert.False(t, limiter.Reserve().OK(), "only the last slot can be released")
+	last.Cancel()
+	assert.True(t, limiter.Reserve().OK())
+	start := time.Now()
+	assert.Nil(t, NewLeakyBucket(100, 0).Wait(context.Background()))
+	assert.Less(t, time.Since(start), 5*time.Millisecond)
+}
+
+func Test_GivenIdleLeakyBucket_WhenAllow_ThenAllowEvent(t *testing.T) {
+	limiter := NewLeakyBucket(100, 0)
+	assert.True(t, limiter.Allow())
+	time.Sleep(15 * time.Millisecond)
+	assert.True(t, limiter.Allow())
+}
+
+func Test_GivenLeakyBucketWithoutRate_WhenReserve_ThenAllowSingleEvent(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewLeakyBucket(rate, 0)
+		assert.True(t, limiter.Reserve().OK())
+		assert.False(t, limiter.Reserve().OK())
+		assert.False(t, limiter.Allow())
+		assert.ErrorIs(t, limiter.Wait(context.Background()), ErrRateLimitExceeded)
+		assert.True(t, NewLeakyBucket(rate, 0).Allow())
+	}
+}
+
+func Test_GivenLeakyBucketWithExtremeRate_WhenReserve_ThenClampInterval(t *testing.T) {
+	fast := NewLeakyBucket(2e9, 0)
+	assert.True(t, fast.Reserve().OK())
+	assert.True(t, fast.Reserve().OK())
+	slow := NewLeakyBucket(1e-12, 1)
+	assert.True(t, slow.Allow())
+	reservation := slow.Reserve()
+	assert.True(t, reservation.OK())
+	assert.Greater(t, reservation.Delay(), 24*time.Hour)
+}
+
+func Test_GivenKeyedLimiter_WhenUseKeys_ThenEachKeyHasItsOwnLimit(t *testing.T) {
+	limiter := NewKeyedLimiter(func() Limiter {
+		return NewTokenBucket(1, 1)
+	})
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("b"))
+	assert.Greater(t, limiter.Reserve("b").Delay(), time.Duration(0))
+	assert.Nil(t, limiter.Wait(context.Background(), "c"))
+}
+
+func Test_GivenExecutorWithRateLimiter_WhenJoinFailOnErrorOrTimeout_ThenReturnOnTimeout(t *testing.T) {
+	var calls int32
+	funcs := make([]Function, 10)
+	for index := range funcs {
+		funcs[index] = func() Return {
+			atomic.AddInt32(&calls, 1)
+			return NewReturn(nil)
+		}
+	}
+	executor := NewExecutor(10, WithRateLimiter(NewTokenBucket(10, 1)))
+	start := time.Now()
+	_, err := executor.JoinFailOnErrorOrTimeout(50*time.Millisecond, funcs...)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_GivenRateLimitedFunctions_WhenJoinCompleteAll_ThenStartAtLimiterRate(t *testing.T) {
+	limiter := NewTokenBucket(50, 1)
+	start := time.Now()
+	returns, _ := JoinCompleteAll(RateLimited(limiter, successFunction), RateLimited(limiter, successFunction), RateLimited(limiter, successFunction))
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+	assert.Nil(t, ErrorOf(returns))
+	ret := RateLimitedContext(NewTokenBucket(0, 1), successContextFunction)(context.Background())
+	assert.Nil(t, ret.Error())
+}
+
+func Test_GivenExecutorWithRateLimiter_WhenLimitExceeded_ThenFunctionIsNotStarted(t *testing.T) {
+	executor := NewExecutor(2, WithRateLimiter(NewTokenBucket(0, 1)))
+	returns, _ := executor.JoinCompleteAll(successFunction, func() Return {
+		t.Error("function must not be started")
+		return NewReturn(nil)
+	})
+	assert.Nil(t, returns[0].Error())
+	assert.ErrorIs(t, returns[1].Error(), ErrRateLimitExceeded)
+	limited := RateLimited(NewTokenBucket(0, 0), successFunction)
+	limited()
+	assert.ErrorIs(t, limited().Error(), ErrRateLimitExceeded)
+}
@@ -0,0 +1,163 @@
+package gauss
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff return the delay before the retry number attempt, attempt is 1 for the first retry and
+// previous is the delay before the previous retry, 0 for the first retry
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// ConstantBackoff wait always delay
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(attempt int, previous time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff wait initial multiplied by multiplier for each retry, at most maxDelay
+func ExponentialBackoff(initial time.Duration, maxDelay time.Duration, multiplier float64) Backoff {
+	return func(attempt int, previous time.Duration) time.Duration {
+		return capDelay(float64(initial)*math.Pow(multiplier, float64(attempt-1)), maxDelay)
+	}
+}
+
+// DecorrelatedJitterBackoff wait a random delay between base and three times the previous delay, at most maxDelay.
+// The delay grows from base, base less or equal than 0 is handled as 1 millisecond
+func DecorrelatedJitterBackoff(base time.Duration, maxDelay time.Duration) Backoff {
+	if base <= 0 {
+		base = time.Millisecond
+	}
+	return func(attempt int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+		return capDelay(float64(base+time.Duration(rand.Int63n(int64(previous*3-base)+1))), maxDelay)
+	}
+}
+
+// FibonacciBackoff wait initial multiplied by the fibonacci number of the retry, at most maxDelay
+func FibonacciBackoff(initial time.Duration, maxDelay time.Duration) Backoff {
+	return func(attempt int, previous time.Duration) time.Duration {
+		current, next := 1.0, 1.0
+		for index := 1; index < attempt; index++ {
+			current, next = next, current+next
+		}
+		return capDelay(float64(initial)*current, maxDelay)
+	}
+}
+
+func capDelay(delay float64, maxDelay time.Duration) time.Duration {
+	if delay > float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// defaultMaxAttempts MaxAttempts of a RetryPolicy without MaxAttempts and MaxElapsedTime
+const defaultMaxAttempts = 3
+
+// RetryPolicy define when and how a function is retried
+type RetryPolicy struct {
+	// MaxAttempts max number of calls including the first one, 0 means no limit when MaxElapsedTime is set
+	// and 3 attempts otherwise, so a policy always stop retrying
+	MaxAttempts int
+	// MaxElapsedTime no retry is started if it would start after MaxElapsedTime since the first call,
+	// 0 means no limit
+	MaxElapsedTime time.Duration
+	// Backoff delay between calls, nil means no delay
+	Backoff Backoff
+	// Retryable return true if the function must be retried after err, nil means every error is retryable
+	Retryable func(err error) bool
+}
+
+// RetryReturn Return of a function wrapped with WithRetry
+type RetryReturn interface {
+	Return
+	// Attempts number of calls to the function
+	Attempts() int
+	// AttemptErrors error of each call that fail
+	AttemptErrors() []error
+}
+
+type retryReturn struct {
+	Return
+	attemptErrors []error
+	attempts      int
+}
+
+func (_self *retryReturn) Attempts() int {
+	return _self.attempts
+}
+
+func (_self *retryReturn) AttemptErrors() []error {
+	return _self.attemptErrors
+}
+
+func (_self *retryReturn) withError(err error) Return {
+	return &retryReturn{Return: withError(_self.Return, err), attemptErrors: _self.attemptErrors, attempts: _self.attempts}
+}
+
+// WithRetry return a Function that call function until it success or the policy stop retrying, the
+// Return is a RetryReturn with the last Return of function. Panics are not retried
+func WithRetry(function Function, policy RetryPolicy) Function {
+	retryFunction := WithRetryContext(contextFunction(function), policy)
+	return func() Return {
+		return retryFunction(context.Background())
+	}
+}
+
+// WithRetryContext return a ContextFunction that call function until it success, the policy stop retrying
+// or the context is done, the Return is a RetryReturn. The wait between calls is interrupted when the
+// context is done, in that case the Return error wrap ErrCancelled or ErrTimeout and the context cause
+func WithRetryContext(function ContextFunction, policy RetryPolicy) ContextFunction {
+	if policy.MaxAttempts <= 0 && policy.MaxElapsedTime <= 0 {
+		policy.MaxAttempts = defaultMaxAttempts
+	}
+	return func(ctx context.Context) Return {
+		start := time.Now()
+		result := &retryReturn{}
+		var delay time.Duration
+		for {
+			result.attempts++
+			result.Return = function(ctx)
+			err := result.Return.Error()
+			if err == nil {
+				return result
+			}
+			result.attemptErrors = append(result.attemptErrors, err)
+			if policy.Retryable != nil && !policy.Retryable(err) {
+				return result
+			}
+			if policy.MaxAttempts > 0 && result.attempts >= policy.MaxAttempts {
+				return result
+			}
+			if policy.Backoff != nil {
+				delay = policy.Backoff(result.attempts, delay)
+			}
+			if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+				return result
+			}
+			if !sleepContext(ctx, delay) {
+				cause := context.Cause(ctx)
+				result.Return = newStatusReturnWithCause(statusOfCause(cause), cause)
+				return result
+			}
+		}
+	}
+}
+
+// sleepContext wait delay, return false if the context is done before
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
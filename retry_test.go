@@ -0,0 +1,125 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingFunction return a function that fail failures times before success
+func failingFunction(failures int) Function {
+	calls := 0
+	return func() Return {
+		calls++
+		if calls <= failures {
+			return NewReturn(errNormal)
+		}
+		return NewReturn(nil, successValue)
+	}
+}
+
+// Backoff tests
+
+func Test_GivenBackoffs_WhenDelay_ThenReturnExpectedDelay(t *testing.T) {
+	assert.Equal(t, time.Second, ConstantBackoff(time.Second)(3, 0))
+	exponential := ExponentialBackoff(time.Millisecond, 10*time.Millisecond, 2)
+	assert.Equal(t, time.Millisecond, exponential(1, 0))
+	assert.Equal(t, 4*time.Millisecond, exponential(3, 0))
+	assert.Equal(t, 10*time.Millisecond, exponential(10, 0))
+	fibonacci := FibonacciBackoff(time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, []time.Duration{1, 1, 2, 3, 5, 8, 10}, []time.Duration{
+		fibonacci(1, 0) / time.Millisecond, fibonacci(2, 0) / time.Millisecond, fibonacci(3, 0) / time.Millisecond,
+		fibonacci(4, 0) / time.Millisecond, fibonacci(5, 0) / time.Millisecond, fibonacci(6, 0) / time.Millisecond,
+		fibonacci(7, 0) / time.Millisecond,
+	})
+}
+
+func Test_GivenDecorrelatedJitterBackoff_WhenDelay_ThenReturnDelayBetweenBaseAndThreeTimesPrevious(t *testing.T) {
+	backoff := DecorrelatedJitterBackoff(time.Millisecond, 100*time.Millisecond)
+	for index := 0; index < 100; index++ {
+		delay := backoff(2, 10*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, time.Millisecond)
+		assert.LessOrEqual(t, delay, 30*time.Millisecond)
+	}
+	assert.LessOrEqual(t, backoff(1, 0), 3*time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, DecorrelatedJitterBackoff(time.Second, 100*time.Millisecond)(1, 0))
+	assert.GreaterOrEqual(t, DecorrelatedJitterBackoff(0, time.Second)(1, 0), time.Millisecond)
+}
+
+// WithRetry tests
+
+func Test_GivenFunctionFailTwice_WhenWithRetry_ThenSuccessAfterThreeAttempts(t *testing.T) {
+	ret := WithRetry(failingFunction(2), RetryPolicy{MaxAttempts: 5, Backoff: ConstantBackoff(time.Millisecond)})()
+	assert.Nil(t, ret.Error())
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+	retryReturn := ret.(RetryReturn)
+	assert.Equal(t, 3, retryReturn.Attempts())
+	assert.Equal(t, []error{errNormal, errNormal}, retryReturn.AttemptErrors())
+}
+
+func Test_GivenAlwaysFailingFunction_WhenWithRetryMaxAttempts_ThenReturnLastError(t *testing.T) {
+	ret := WithRetry(errorFunction, RetryPolicy{MaxAttempts: 3})()
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	assert.Equal(t, 3, ret.(RetryReturn).Attempts())
+}
+
+func Test_GivenNotRetryableError_WhenWithRetry_ThenReturnAfterFirstAttempt(t *testing.T) {
+	errNotRetryable := errors.New("not retryable")
+	ret := WithRetry(func() Return {
+		return NewReturn(errNotRetryable)
+	}, RetryPolicy{Retryable: func(err error) bool {
+		return !errors.Is(err, errNotRetryable)
+	}})()
+	assert.ErrorIs(t, ret.Error(), errNotRetryable)
+	assert.Equal(t, 1, ret.(RetryReturn).Attempts())
+}
+
+func Test_GivenZeroValuePolicy_WhenWithRetry_ThenStopAfterDefaultMaxAttempts(t *testing.T) {
+	ret := WithRetry(errorFunction, RetryPolicy{})()
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	assert.Equal(t, 3, ret.(RetryReturn).Attempts())
+}
+
+func Test_GivenMaxElapsedTime_WhenWithRetry_ThenStopBeforeExceedIt(t *testing.T) {
+	ret := WithRetry(errorFunction, RetryPolicy{MaxElapsedTime: 50 * time.Millisecond, Backoff: ConstantBackoff(20 * time.Millisecond)})()
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	assert.Equal(t, 3, ret.(RetryReturn).Attempts())
+}
+
+func Test_GivenCancelledContextDuringBackoff_WhenWithRetryContext_ThenReturnCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	ret := WithRetryContext(errorContextFunction, RetryPolicy{Backoff: ConstantBackoff(time.Second)})(ctx)
+	assert.Equal(t, StatusTimedOut, StatusOf(ret))
+	assert.Equal(t, []error{errNormal}, ret.(RetryReturn).AttemptErrors())
+}
+
+func Test_GivenRetryFunction_WhenJoinFailOnAnyError_ThenSlotContainsRetryReturn(t *testing.T) {
+	returns, err := JoinFailOnAnyError(WithRetry(failingFunction(1), RetryPolicy{MaxAttempts: 2}), successFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, returns[0].(RetryReturn).Attempts())
+}
+
+func Test_GivenRetryOfTimeoutFunction_WhenJoinCompleteAll_ThenTimeoutErrorHasSlotIndex(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, WithRetry(WithTimeout(successFunctionAfter50Ms, time.Millisecond), RetryPolicy{MaxAttempts: 2}))
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, returns[1].Error(), &timeoutError)
+	assert.Equal(t, 1, timeoutError.Index)
+	assert.Equal(t, 2, returns[1].(RetryReturn).Attempts())
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[1]))
+}
+
+func Test_GivenNamedRetryFunction_WhenJoinCompleteAll_ThenSlotKeepNameAndAttempts(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction,
+		Named("db", WithRetry(errorFunction, RetryPolicy{MaxAttempts: 2})),
+		Named("cache", WithRetry(WithTimeout(successFunctionAfter50Ms, time.Millisecond), RetryPolicy{MaxAttempts: 2})))
+	retryReturn, ok := returns[1].(RetryReturn)
+	assert.True(t, ok, "Named must keep the RetryReturn interface")
+	assert.Equal(t, 2, retryReturn.Attempts())
+	assert.Equal(t, []error{errNormal, errNormal}, retryReturn.AttemptErrors())
+	assert.Equal(t, 2, returns[2].(RetryReturn).Attempts())
+	assert.EqualError(t, ErrorOf(returns), "function 1 (db): err-normal\nfunction 2 (cache): timeout after 1ms")
+}
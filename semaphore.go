@@ -0,0 +1,127 @@
+package gauss
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrWeightExceeded error returned by Acquire when the weight is greater than the semaphore size
+	ErrWeightExceeded = errors.New("weight exceeds semaphore size")
+)
+
+type semaphoreWaiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+// Semaphore weighted semaphore, waiters acquire in FIFO order so a large weight is not starved by small ones
+type Semaphore struct {
+	mutex   sync.Mutex
+	size    int64
+	current int64
+	waiters list.List
+}
+
+// NewSemaphore create a Semaphore with size total weight
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire acquire weight, blocking until it is available or the context is done, in that case return the
+// context cause. Return ErrWeightExceeded when weight is greater than the size
+func (_self *Semaphore) Acquire(ctx context.Context, weight int64) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	_self.mutex.Lock()
+	if weight > _self.size {
+		_self.mutex.Unlock()
+		return ErrWeightExceeded
+	}
+	if _self.size-_self.current >= weight && _self.waiters.Len() == 0 {
+		_self.current += weight
+		_self.mutex.Unlock()
+		return nil
+	}
+	waiter := &semaphoreWaiter{weight: weight, ready: make(chan struct{})}
+	element := _self.waiters.PushBack(waiter)
+	_self.mutex.Unlock()
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+	}
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	select {
+	case <-waiter.ready:
+		// acquired while the context was done, give the weight back
+		_self.current -= weight
+	default:
+		_self.waiters.Remove(element)
+	}
+	// the waiter may have blocked smaller waiters behind it
+	_self.notifyWaiters()
+	return context.Cause(ctx)
+}
+
+// TryAcquire acquire weight without blocking, return false when weight is not available or there are waiters
+func (_self *Semaphore) TryAcquire(weight int64) bool {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	if _self.size-_self.current < weight || _self.waiters.Len() > 0 {
+		return false
+	}
+	_self.current += weight
+	return true
+}
+
+// Release release weight, panic when releasing more than acquired
+func (_self *Semaphore) Release(weight int64) {
+	_self.mutex.Lock()
+	defer _self.mutex.Unlock()
+	_self.current -= weight
+	if _self.current < 0 {
+		panic("semaphore: released more than acquired")
+	}
+	_self.notifyWaiters()
+}
+
+// notifyWaiters wake waiters in FIFO order while the first waiter weight is available
+func (_self *Semaphore) notifyWaiters() {
+	for element := _self.waiters.Front(); element != nil; element = _self.waiters.Front() {
+		waiter := element.Value.(*semaphoreWaiter)
+		if _self.size-_self.current < waiter.weight {
+			return
+		}
+		_self.current += waiter.weight
+		_self.waiters.Remove(element)
+		close(waiter.ready)
+	}
+}
+
+// WrapContext return a ContextFunction that acquire weight before call function and release it after, when
+// Acquire fail the Return contains its error and function is not called
+func (_self *Semaphore) WrapContext(weight int64, function ContextFunction) ContextFunction {
+	return func(ctx context.Context) Return {
+		if err := _self.Acquire(ctx, weight); err != nil {
+			return NewReturn(err)
+		}
+		defer _self.Release(weight)
+		return function(ctx)
+	}
+}
+
+// Wrap return a Function that acquire weight before call function and release it after, so functions run by
+// any join are bounded by their total weight
+func (_self *Semaphore) Wrap(weight int64, function Function) Function {
+	wrapped := _self.WrapContext(weight, func(ctx context.Context) Return {
+		return function()
+	})
+	return func() Return {
+		return wrapped(context.Background())
+	}
+}
@@ -0,0 +1,156 @@
+package gauss
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitSemaphoreWaiters poll semaphore until it has count waiters
+func waitSemaphoreWaiters(semaphore *Semaphore, count int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		semaphore.mutex.Lock()
+		waiters := semaphore.waiters.Len()
+		semaphore.mutex.Unlock()
+		if waiters == count {
+			return
+		}
+	}
+}
+
+func Test_GivenAvailableWeight_WhenTryAcquire_ThenAcquireUntilSizeReached(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.True(t, semaphore.TryAcquire(6))
+	assert.False(t, semaphore.TryAcquire(5))
+	assert.True(t, semaphore.TryAcquire(4))
+	semaphore.Release(10)
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenWeightGreaterThanSize_WhenAcquire_ThenReturnErrWeightExceeded(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.ErrorIs(t, semaphore.Acquire(context.Background(), 11), ErrWeightExceeded)
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenLargeWaiter_WhenSmallRequestsArrive_ThenLargeWaiterAcquireFirst(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.Nil(t, semaphore.Acquire(context.Background(), 5))
+	order := make(chan int64, 2)
+	go func() {
+		assert.Nil(t, semaphore.Acquire(context.Background(), 10))
+		order <- 10
+		semaphore.Release(10)
+	}()
+	waitSemaphoreWaiters(semaphore, 1)
+	assert.False(t, semaphore.TryAcquire(1), "small request must not overtake a waiter")
+	go func() {
+		assert.Nil(t, semaphore.Acquire(context.Background(), 1))
+		order <- 1
+		semaphore.Release(1)
+	}()
+	waitSemaphoreWaiters(semaphore, 2)
+	semaphore.Release(5)
+	assert.Equal(t, int64(10), <-order)
+	assert.Equal(t, int64(1), <-order)
+}
+
+func Test_GivenCancelledContext_WhenAcquire_ThenReturnCause(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errNormal)
+	assert.ErrorIs(t, semaphore.Acquire(ctx, 1), errNormal)
+	assert.True(t, semaphore.TryAcquire(10), "failed acquire must not hold weight")
+}
+
+func Test_GivenFirstWaiterTimedOut_WhenAcquire_ThenWakeFollowingWaiters(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.Nil(t, semaphore.Acquire(context.Background(), 5))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	acquired := make(chan error, 1)
+	go func() {
+		waitSemaphoreWaiters(semaphore, 1)
+		acquired <- semaphore.Acquire(context.Background(), 5)
+	}()
+	assert.ErrorIs(t, semaphore.Acquire(ctx, 10), context.DeadlineExceeded)
+	assert.Nil(t, <-acquired)
+	assert.False(t, semaphore.TryAcquire(1))
+	semaphore.Release(10)
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenWaiterWokenWhenContextDone_WhenAcquire_ThenGiveWeightBack(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.True(t, semaphore.TryAcquire(10))
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan error, 1)
+	go func() {
+		returned <- semaphore.Acquire(ctx, 10)
+	}()
+	waitSemaphoreWaiters(semaphore, 1)
+	// wake the waiter while it wait the lock after seeing its context done
+	semaphore.mutex.Lock()
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	semaphore.current = 0
+	semaphore.notifyWaiters()
+	semaphore.mutex.Unlock()
+	if <-returned == nil {
+		semaphore.Release(10)
+	}
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenOverRelease_WhenRelease_ThenPanic(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	assert.True(t, semaphore.TryAcquire(1))
+	assert.Panics(t, func() {
+		semaphore.Release(2)
+	})
+}
+
+func Test_GivenWrappedFunctions_WhenJoinCompleteAll_ThenRunningWeightIsBounded(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	var running, maxRunning int32
+	function := concurrencyFunction(&running, &maxRunning)
+	weighted := func() Return {
+		semaphore.mutex.Lock()
+		current := semaphore.current
+		semaphore.mutex.Unlock()
+		assert.LessOrEqual(t, current, int64(10))
+		return function()
+	}
+	funcs := []Function{
+		semaphore.Wrap(6, weighted), semaphore.Wrap(6, weighted), semaphore.Wrap(4, weighted),
+		semaphore.Wrap(11, weighted),
+	}
+	returns, _ := JoinCompleteAll(funcs...)
+	for _, ret := range returns[:3] {
+		assert.Nil(t, ret.Error())
+	}
+	assert.ErrorIs(t, returns[3].Error(), ErrWeightExceeded)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenPanicFunction_WhenWrap_ThenReleaseWeight(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	returns, _ := JoinCompleteAll(semaphore.Wrap(10, panicFunction))
+	assert.Equal(t, StatusPanicked, StatusOf(returns[0]))
+	assert.True(t, semaphore.TryAcquire(10))
+}
+
+func Test_GivenCancelledContext_WhenWrapContext_ThenDoNotCallFunction(t *testing.T) {
+	semaphore := NewSemaphore(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ret := semaphore.WrapContext(1, func(ctx context.Context) Return {
+		t.Error("function must not be called")
+		return NewReturn(nil)
+	})(ctx)
+	assert.ErrorIs(t, ret.Error(), context.Canceled)
+}
@@ -0,0 +1,87 @@
+package gauss
+
+import (
+	"context"
+	"time"
+)
+
+// Settlement outcome of a function in JoinSettled
+type Settlement struct {
+	// Status of the function
+	Status Status
+	// Return of the function
+	Return Return
+	// ReturnValues return values of the function
+	ReturnValues []interface{}
+	// Err error of the function, *PanicError when the function panic
+	Err error
+	// Started time when the function was started, zero if the function was not started
+	Started time.Time
+	// Duration time taken by the function, zero if the function did not complete
+	Duration time.Duration
+}
+
+func newSettlement(ret Return, started time.Time, duration time.Duration) Settlement {
+	return Settlement{
+		Status:       StatusOf(ret),
+		Return:       ret,
+		ReturnValues: ret.ReturnValues(),
+		Err:          ret.Error(),
+		Started:      started,
+		Duration:     duration,
+	}
+}
+
+// joinSettled wait until all functions complete or parent context done, functions that do not complete
+// before the context is done are settled with StatusCancelled or StatusTimedOut
+func joinSettled(parent context.Context, submit submitFunction, funcs []ContextFunction) []Settlement {
+	settlements := make([]Settlement, len(funcs))
+	if parent.Err() != nil {
+		for index, ret := range doneReturns(len(funcs), context.Cause(parent)) {
+			settlements[index] = newSettlement(ret, time.Time{}, 0)
+		}
+		return settlements
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	completed := make([]bool, len(funcs))
+	completions := startFunctions(ctx, submit, funcs)
+	for range funcs {
+		select {
+		case c := <-completions:
+			settlements[c.index] = newSettlement(c.ret, c.started, c.duration)
+			completed[c.index] = true
+		case <-ctx.Done():
+			cause := context.Cause(ctx)
+			for index := range settlements {
+				if !completed[index] {
+					settlements[index] = newSettlement(newStatusReturnWithCause(statusOfCause(cause), cause), time.Time{}, 0)
+				}
+			}
+			return settlements
+		}
+	}
+	return settlements
+}
+
+// JoinSettled Run functions and return when all functions complete, each Settlement contains the Status,
+// Return and timing of the function with the same index
+func JoinSettled(funcs ...Function) []Settlement {
+	return joinSettled(context.Background(), spawnGoroutine, contextFunctions(funcs))
+}
+
+// JoinSettledContext Run functions and return when all functions complete or the context is done,
+// functions that do not complete before are settled with StatusCancelled or StatusTimedOut
+func JoinSettledContext(ctx context.Context, funcs ...ContextFunction) []Settlement {
+	return joinSettled(ctx, spawnGoroutine, funcs)
+}
+
+// JoinSettled JoinSettled running functions with the executor
+func (_self *Executor) JoinSettled(funcs ...Function) []Settlement {
+	return joinSettled(context.Background(), _self.submit, contextFunctions(funcs))
+}
+
+// JoinSettledContext JoinSettledContext running functions with the executor
+func (_self *Executor) JoinSettledContext(ctx context.Context, funcs ...ContextFunction) []Settlement {
+	return joinSettled(ctx, _self.submit, funcs)
+}
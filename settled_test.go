@@ -0,0 +1,50 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenMixedFunctions_WhenJoinSettled_ThenReturnStatusOfEachFunction(t *testing.T) {
+	settlements := JoinSettled(successFunctionAfter50Ms, errorFunction, panicFunction, WithTimeout(successFunctionAfter50Ms, 10*time.Millisecond))
+	assert.Equal(t, StatusFulfilled, settlements[0].Status)
+	assert.Equal(t, []interface{}{successValue}, settlements[0].ReturnValues)
+	assert.GreaterOrEqual(t, settlements[0].Duration, 50*time.Millisecond)
+	assert.False(t, settlements[0].Started.IsZero())
+	assert.Equal(t, StatusRejected, settlements[1].Status)
+	assert.ErrorIs(t, settlements[1].Err, errNormal)
+	assert.Equal(t, StatusPanicked, settlements[2].Status)
+	var panicError *PanicError
+	assert.ErrorAs(t, settlements[2].Err, &panicError)
+	assert.Equal(t, StatusTimedOut, settlements[3].Status)
+	assert.NotNil(t, settlements[3].Return)
+}
+
+func Test_GivenContextDeadline_WhenJoinSettledContext_ThenSlowFunctionsAreTimedOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	settlements := JoinSettledContext(ctx, successContextFunction, contextFunction(successFunctionAfter50Ms))
+	assert.Equal(t, StatusFulfilled, settlements[0].Status)
+	assert.Equal(t, StatusTimedOut, settlements[1].Status)
+	assert.True(t, settlements[1].Started.IsZero())
+}
+
+func Test_GivenCancelledContext_WhenJoinSettledContext_ThenAllFunctionsAreCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	settlements := JoinSettledContext(ctx, successContextFunction, errorContextFunction)
+	assert.Equal(t, StatusCancelled, settlements[0].Status)
+	assert.Equal(t, StatusCancelled, settlements[1].Status)
+}
+
+func Test_GivenExecutor_WhenJoinSettled_ThenReturnStatusOfEachFunction(t *testing.T) {
+	executor := NewExecutor(1, WithQueueFullPolicy(QueueFullReject))
+	settlements := executor.JoinSettled(successFunctionAfter50Ms, successFunction)
+	assert.Equal(t, StatusFulfilled, settlements[0].Status)
+	assert.ErrorIs(t, settlements[1].Err, ErrQueueFull)
+	settlements = executor.JoinSettledContext(context.Background(), errorContextFunction)
+	assert.Equal(t, StatusRejected, settlements[0].Status)
+}
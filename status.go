@@ -0,0 +1,132 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPending error of the Return of a function still running when the join outcome was decided
+	ErrPending = errors.New("pending")
+	// ErrCancelled error of the Return of a function still running when the join context was cancelled
+	ErrCancelled = errors.New("cancelled")
+)
+
+// Status of a function in a join
+type Status int
+
+const (
+	// StatusPending function still running when the join outcome was decided by other function
+	StatusPending Status = iota
+	// StatusFulfilled function return without error
+	StatusFulfilled
+	// StatusRejected function return an error
+	StatusRejected
+	// StatusPanicked function panic
+	StatusPanicked
+	// StatusTimedOut function timeout or still running when the join timeout
+	StatusTimedOut
+	// StatusCancelled function cancelled or still running when the join context was cancelled
+	StatusCancelled
+)
+
+func (_self Status) String() string {
+	switch _self {
+	case StatusPending:
+		return "pending"
+	case StatusFulfilled:
+		return "fulfilled"
+	case StatusRejected:
+		return "rejected"
+	case StatusPanicked:
+		return "panicked"
+	case StatusTimedOut:
+		return "timed out"
+	case StatusCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// statusReturn Return of a function that did not complete before the join returned
+type statusReturn struct {
+	status Status
+	err    error
+}
+
+func (_self *statusReturn) Error() error {
+	return _self.err
+}
+
+func (_self *statusReturn) ReturnValues() []interface{} {
+	return nil
+}
+
+func (_self *statusReturn) Status() Status {
+	return _self.status
+}
+
+func (_self *statusReturn) withError(err error) Return {
+	return &statusReturn{status: _self.status, err: err}
+}
+
+func newStatusReturn(status Status) Return {
+	return newStatusReturnWithCause(status, nil)
+}
+
+// newStatusReturnWithCause create a Return with status, its error wrap the status error and the cause
+func newStatusReturnWithCause(status Status, cause error) Return {
+	err := ErrPending
+	switch status {
+	case StatusTimedOut:
+		err = ErrTimeout
+	case StatusCancelled:
+		err = ErrCancelled
+	default:
+		status = StatusPending
+	}
+	if cause != nil && !errors.Is(cause, err) {
+		err = fmt.Errorf("%w: %w", err, cause)
+	}
+	return &statusReturn{status: status, err: err}
+}
+
+// StatusOf return the Status of a Return
+func StatusOf(ret Return) Status {
+	if withStatus, ok := ret.(interface{ Status() Status }); ok {
+		return withStatus.Status()
+	}
+	err := ret.Error()
+	var panicError *PanicError
+	switch {
+	case err == nil:
+		return StatusFulfilled
+	case errors.As(err, &panicError):
+		return StatusPanicked
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return StatusTimedOut
+	case errors.Is(err, ErrCancelled), errors.Is(err, context.Canceled):
+		return StatusCancelled
+	}
+	return StatusRejected
+}
+
+// statusOfCause return the status of functions still running when the join context is done with cause
+func statusOfCause(cause error) Status {
+	if errors.Is(cause, ErrTimeout) || errors.Is(cause, context.DeadlineExceeded) {
+		return StatusTimedOut
+	}
+	return StatusCancelled
+}
+
+// fillIncomplete set a Return with status to every slot of a function that did not complete,
+// returns never contain nil values
+func fillIncomplete(returns []Return, status Status) []Return {
+	for index, ret := range returns {
+		if ret == nil {
+			returns[index] = newStatusReturn(status)
+		}
+	}
+	return returns
+}
@@ -0,0 +1,92 @@
+package gauss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Status tests
+
+func Test_GivenStatuses_WhenString_ThenReturnName(t *testing.T) {
+	assert.Equal(t, "pending", StatusPending.String())
+	assert.Equal(t, "fulfilled", StatusFulfilled.String())
+	assert.Equal(t, "rejected", StatusRejected.String())
+	assert.Equal(t, "panicked", StatusPanicked.String())
+	assert.Equal(t, "timed out", StatusTimedOut.String())
+	assert.Equal(t, "cancelled", StatusCancelled.String())
+	assert.Equal(t, "unknown", Status(-1).String())
+}
+
+func Test_GivenReturns_WhenStatusOf_ThenReturnExpectedStatus(t *testing.T) {
+	assert.Equal(t, StatusFulfilled, StatusOf(NewReturn(nil)))
+	assert.Equal(t, StatusRejected, StatusOf(NewReturn(errNormal)))
+	assert.Equal(t, StatusPanicked, StatusOf(NewReturn(&PanicError{Value: "panic"})))
+	assert.Equal(t, StatusTimedOut, StatusOf(NewReturn(context.DeadlineExceeded)))
+	assert.Equal(t, StatusCancelled, StatusOf(NewReturn(context.Canceled)))
+	assert.Equal(t, StatusPending, StatusOf(newStatusReturn(StatusPending)))
+}
+
+// Snapshot tests
+
+func Test_GivenRunningFunction_WhenJoinFailOnAnyErrorFail_ThenSlotIsPending(t *testing.T) {
+	returns, err := JoinFailOnAnyError(errorFunction, successFunctionAfter50Ms)
+	assert.ErrorIs(t, err, errNormal)
+	assert.Equal(t, StatusRejected, StatusOf(returns[0]))
+	assert.Equal(t, StatusPending, StatusOf(returns[1]))
+	assert.ErrorIs(t, returns[1].Error(), ErrPending)
+	assert.Nil(t, returns[1].ReturnValues())
+	// the snapshot does not change when the running function complete
+	time.Sleep(100 * time.Millisecond)
+	assert.ErrorIs(t, returns[1].Error(), ErrPending)
+}
+
+func Test_GivenRunningFunction_WhenJoinFailOnErrorOrTimeoutTimeout_ThenSlotIsTimedOut(t *testing.T) {
+	returns, err := JoinFailOnErrorOrTimeout(10*time.Millisecond, successFunction, successFunctionAfter50Ms)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, StatusFulfilled, StatusOf(returns[0]))
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[1]))
+	assert.ErrorIs(t, returns[1].Error(), ErrTimeout)
+}
+
+func Test_GivenRunningFunction_WhenJoinCompleteOnAnySuccessSucceed_ThenSlotIsPending(t *testing.T) {
+	returns, isSuccess := JoinCompleteOnAnySuccess(successFunctionAfter50Ms, successFunction)
+	assert.True(t, isSuccess)
+	assert.Equal(t, StatusPending, StatusOf(returns[0]))
+}
+
+func Test_GivenCancelledContext_WhenJoinFailOnAnyErrorContext_ThenSlotIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	returns, err := JoinFailOnAnyErrorContext(ctx, contextFunction(successFunctionAfter50Ms))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+	assert.ErrorIs(t, returns[0].Error(), ErrCancelled)
+}
+
+func Test_GivenContextDeadline_WhenJoinCompleteOnAnySuccessContext_ThenSlotIsTimedOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	returns, isSuccess := JoinCompleteOnAnySuccessContext(ctx, contextFunction(successFunctionAfter50Ms))
+	assert.False(t, isSuccess)
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[0]))
+}
+
+func Test_GivenRunningFunction_WhenJoinFailOnAnyErrorSuccessFailFunction_ThenFailFunctionReceiveSnapshot(t *testing.T) {
+	JoinFailOnAnyErrorSuccessFailFunction(func(returns []Return) {
+		assert.True(t, false, "JoinFailOnAnyErrorSuccessFailFunction must no call success function")
+	}, func(returns []Return, err error) {
+		assert.Equal(t, StatusPending, StatusOf(returns[1]))
+		assert.True(t, errors.Is(returns[1].Error(), ErrPending))
+	}, errorFunction, successFunctionAfter50Ms)
+}
+
+func Test_GivenCause_WhenNewStatusReturnWithCause_ThenErrorWrapStatusErrorAndCause(t *testing.T) {
+	ret := newStatusReturnWithCause(StatusCancelled, errNormal)
+	assert.ErrorIs(t, ret.Error(), ErrCancelled)
+	assert.ErrorIs(t, ret.Error(), errNormal)
+	assert.Equal(t, ErrTimeout, newStatusReturnWithCause(StatusTimedOut, ErrTimeout).Error())
+}
@@ -0,0 +1,49 @@
+package gauss
+
+import "context"
+
+// IndexedReturn Return of the function with index Index
+type IndexedReturn struct {
+	Index  int
+	Return Return
+}
+
+// joinStream send the Return of each function to the returned channel in completion order, the channel is
+// closed when all functions complete or parent context done. The channel is buffered with capacity for all
+// functions so an abandoned channel does not block any goroutine
+func joinStream(parent context.Context, submit submitFunction, funcs []ContextFunction) <-chan IndexedReturn {
+	stream := make(chan IndexedReturn, len(funcs))
+	if parent.Err() != nil {
+		close(stream)
+		return stream
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	completions := startFunctions(ctx, submit, funcs)
+	go func() {
+		defer close(stream)
+		defer cancel(nil)
+		for range funcs {
+			select {
+			case c := <-completions:
+				if ctx.Err() != nil {
+					return
+				}
+				stream <- IndexedReturn{Index: c.index, Return: c.ret}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream
+}
+
+// JoinStream Run functions and send the Return of each function as soon as it completes, tagged with its
+// index. The channel is closed when all functions complete or the context is done
+func JoinStream(ctx context.Context, funcs ...ContextFunction) <-chan IndexedReturn {
+	return joinStream(ctx, spawnGoroutine, funcs)
+}
+
+// JoinStream JoinStream running functions with the executor
+func (_self *Executor) JoinStream(ctx context.Context, funcs ...ContextFunction) <-chan IndexedReturn {
+	return joinStream(ctx, _self.submit, funcs)
+}
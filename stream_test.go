@@ -0,0 +1,67 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenFunctions_WhenJoinStream_ThenEmitReturnsInCompletionOrder(t *testing.T) {
+	stream := JoinStream(context.Background(), contextFunction(successFunctionAfter50Ms), errorContextFunction)
+	first := <-stream
+	assert.Equal(t, 1, first.Index)
+	assert.ErrorIs(t, first.Return.Error(), errNormal)
+	second := <-stream
+	assert.Equal(t, 0, second.Index)
+	assert.Equal(t, successValue, second.Return.ReturnValues()[0])
+	_, open := <-stream
+	assert.False(t, open, "JoinStream must close the channel when all functions complete")
+}
+
+func Test_GivenFunctionDoPanic_WhenJoinStream_ThenEmitPanicError(t *testing.T) {
+	indexedReturn := <-JoinStream(context.Background(), panicContextFunction)
+	assert.Equal(t, StatusPanicked, StatusOf(indexedReturn.Return))
+}
+
+func Test_GivenContextCancelled_WhenJoinStream_ThenCloseChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started, causes := make(chan struct{}), make(chan error, 1)
+	stream := JoinStream(ctx, successContextFunction, causeContextFunction(started, causes))
+	assert.Equal(t, 0, (<-stream).Index)
+	<-started
+	cancel()
+	_, open := <-stream
+	assert.False(t, open, "JoinStream must close the channel when the context is cancelled")
+	assert.ErrorIs(t, <-causes, context.Canceled)
+}
+
+func Test_GivenCancelledContext_WhenJoinStream_ThenReturnClosedChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, open := <-JoinStream(ctx, successContextFunction)
+	assert.False(t, open)
+}
+
+func Test_GivenAbandonedStream_WhenFunctionsComplete_ThenNoGoroutineLeak(t *testing.T) {
+	assertNoGoroutineLeak(t, func() {
+		NewExecutor(2).JoinStream(context.Background(), successContextFunction, contextFunction(successFunctionAfter50Ms), errorContextFunction)
+	})
+}
+
+func Test_GivenContextCancelledAfterCompletion_WhenJoinStream_ThenDoNotEmitAfterCancel(t *testing.T) {
+	for attempt := 0; attempt < 50; attempt++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		// cancel right after the completion is sent, while the stream goroutine wake up to receive it
+		submit := func(ctx context.Context, task func()) error {
+			task()
+			cancel()
+			return nil
+		}
+		emitted := 0
+		for range joinStream(ctx, submit, []ContextFunction{successContextFunction}) {
+			emitted++
+		}
+		assert.LessOrEqual(t, emitted, 1)
+	}
+}
@@ -0,0 +1,43 @@
+package gauss
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout return a Function that return a Return with a *TimeoutError when function does not complete
+// before timeout, function keeps running but its Return is ignored
+func WithTimeout(function Function, timeout time.Duration) Function {
+	timeoutFunction := WithTimeoutContext(contextFunction(function), timeout)
+	return func() Return {
+		return timeoutFunction(context.Background())
+	}
+}
+
+// WithTimeoutContext return a ContextFunction that return a Return with a *TimeoutError when function does
+// not complete before timeout, the context received by function is cancelled with the *TimeoutError as cause.
+// When used in a join the index of the *TimeoutError is the index of the function in the join
+func WithTimeoutContext(function ContextFunction, timeout time.Duration) ContextFunction {
+	return func(parent context.Context) Return {
+		ctx, cancel := context.WithCancelCause(parent)
+		returns := make(chan Return, 1)
+		go func() {
+			returns <- runFunction(ctx, -1, function)
+		}()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case ret := <-returns:
+			cancel(nil)
+			return ret
+		case <-timer.C:
+			err := &TimeoutError{Index: -1, Timeout: timeout}
+			cancel(err)
+			return &statusReturn{status: StatusTimedOut, err: err}
+		case <-parent.Done():
+			cause := context.Cause(parent)
+			cancel(cause)
+			return newStatusReturnWithCause(statusOfCause(cause), cause)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GivenSlowFunction_WhenWithTimeout_ThenReturnTimeoutError(t *testing.T) {
+	ret := WithTimeout(successFunctionAfter50Ms, 10*time.Millisecond)()
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, ret.Error(), &timeoutError)
+	assert.ErrorIs(t, ret.Error(), ErrTimeout)
+	assert.Equal(t, -1, timeoutError.Index)
+	assert.Equal(t, 10*time.Millisecond, timeoutError.Timeout)
+	assert.Equal(t, StatusTimedOut, StatusOf(ret))
+}
+
+func Test_GivenFastFunction_WhenWithTimeout_ThenReturnFunctionReturn(t *testing.T) {
+	ret := WithTimeout(successFunction, time.Second)()
+	assert.Equal(t, successValue, ret.ReturnValues()[0])
+}
+
+func Test_GivenSlowFunction_WhenWithTimeoutContext_ThenCancelContextWithTimeoutErrorCause(t *testing.T) {
+	started, causes := make(chan struct{}), make(chan error, 1)
+	ret := WithTimeoutContext(causeContextFunction(started, causes), 10*time.Millisecond)(context.Background())
+	assert.ErrorIs(t, ret.Error(), ErrTimeout)
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, <-causes, &timeoutError)
+}
+
+func Test_GivenCancelledParent_WhenWithTimeoutContext_ThenReturnCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ret := WithTimeoutContext(contextFunction(successFunctionAfter50Ms), time.Second)(ctx)
+	assert.Equal(t, StatusCancelled, StatusOf(ret))
+}
+
+func Test_GivenPerFunctionTimeouts_WhenJoinCompleteAll_ThenSlotErrorIsTimeoutErrorWithIndex(t *testing.T) {
+	returns, isSuccess := JoinCompleteAll(WithTimeout(successFunction, 20*time.Millisecond),
+		WithTimeout(successFunctionAfter50Ms, 10*time.Millisecond))
+	assert.False(t, isSuccess)
+	assert.Nil(t, returns[0].Error())
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, returns[1].Error(), &timeoutError)
+	assert.Equal(t, 1, timeoutError.Index)
+	assert.EqualError(t, timeoutError, "function 1: timeout after 10ms")
+}
+
+func Test_GivenPanicFunctionWithTimeout_WhenJoinCompleteAll_ThenPanicErrorHasIndex(t *testing.T) {
+	returns, _ := JoinCompleteAll(successFunction, WithTimeout(panicFunction, time.Second))
+	var panicError *PanicError
+	assert.ErrorAs(t, returns[1].Error(), &panicError)
+	assert.Equal(t, 1, panicError.Index)
+}
+
+func Test_GivenGroupDeadlineAndPerFunctionTimeout_WhenJoinCompleteAllContext_ThenRespectBoth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	returns, isSuccess := JoinCompleteAllContext(ctx, successContextFunction,
+		WithTimeoutContext(contextFunction(successFunctionAfter50Ms), 10*time.Millisecond),
+		contextFunction(successFunctionAfter200Ms))
+	assert.False(t, isSuccess)
+	assert.Equal(t, StatusFulfilled, StatusOf(returns[0]))
+	var timeoutError *TimeoutError
+	assert.ErrorAs(t, returns[1].Error(), &timeoutError)
+	assert.Equal(t, 1, timeoutError.Index)
+	assert.Equal(t, StatusTimedOut, StatusOf(returns[2]))
+}
+
+func Test_GivenCancelledContext_WhenJoinCompleteAllContext_ThenReturnCancelledSlots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	returns, isSuccess := JoinCompleteAllContext(ctx, successContextFunction)
+	assert.False(t, isSuccess)
+	assert.Equal(t, StatusCancelled, StatusOf(returns[0]))
+	returns, isSuccess = NewExecutor(1).JoinCompleteAllContext(context.Background(), successContextFunction)
+	assert.True(t, isSuccess)
+	assert.Len(t, returns, 1)
+}
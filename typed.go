@@ -0,0 +1,221 @@
+package gauss
+
+import (
+	"context"
+	"time"
+)
+
+// Result typed value and error returned by a TypedFunction
+type Result[T any] interface {
+	Value() T
+	Err() error
+}
+
+type result[T any] struct {
+	value T
+	err   error
+}
+
+func (_self *result[T]) Value() T {
+	return _self.value
+}
+
+func (_self *result[T]) Err() error {
+	return _self.err
+}
+
+// NewResult create a Result with value and error
+func NewResult[T any](value T, err error) Result[T] {
+	return &result[T]{value: value, err: err}
+}
+
+type TypedFunction[T any] func() (T, error)
+type TypedContextFunction[T any] func(ctx context.Context) (T, error)
+type TypedSuccessFunction[T any] func(results []Result[T])
+type TypedFailFunction[T any] func(results []Result[T], err error)
+
+// typedReturn Return implementation that keep the typed value, ReturnValues contains only the value
+type typedReturn[T any] struct {
+	result[T]
+}
+
+func (_self *typedReturn[T]) Error() error {
+	return _self.err
+}
+
+func (_self *typedReturn[T]) ReturnValues() []interface{} {
+	return []interface{}{_self.value}
+}
+
+func (_self *typedReturn[T]) withError(err error) Return {
+	return &typedReturn[T]{result[T]{value: _self.value, err: err}}
+}
+
+// Function convert a TypedFunction to a Function, so it can be joined with untyped functions
+func (_self TypedFunction[T]) Function() Function {
+	return func() Return {
+		value, err := _self()
+		return &typedReturn[T]{result[T]{value: value, err: err}}
+	}
+}
+
+// ContextFunction convert a TypedContextFunction to a ContextFunction
+func (_self TypedContextFunction[T]) ContextFunction() ContextFunction {
+	return func(ctx context.Context) Return {
+		value, err := _self(ctx)
+		return &typedReturn[T]{result[T]{value: value, err: err}}
+	}
+}
+
+// ResultOf convert a Return to a Result, value is the first return value when it is of type T, zero value otherwise
+func ResultOf[T any](ret Return) Result[T] {
+	if ret == nil {
+		return nil
+	}
+	if typed, ok := ret.(*typedReturn[T]); ok {
+		return &typed.result
+	}
+	var value T
+	if returnValues := ret.ReturnValues(); len(returnValues) > 0 {
+		if typedValue, ok := returnValues[0].(T); ok {
+			value = typedValue
+		}
+	}
+	return &result[T]{value: value, err: ret.Error()}
+}
+
+func functionsOf[T any](funcs []TypedFunction[T]) []Function {
+	functions := make([]Function, len(funcs))
+	for index, function := range funcs {
+		functions[index] = function.Function()
+	}
+	return functions
+}
+
+func contextFunctionsOf[T any](funcs []TypedContextFunction[T]) []ContextFunction {
+	functions := make([]ContextFunction, len(funcs))
+	for index, function := range funcs {
+		functions[index] = function.ContextFunction()
+	}
+	return functions
+}
+
+func resultsOf[T any](returns []Return) []Result[T] {
+	results := make([]Result[T], len(returns))
+	for index, ret := range returns {
+		results[index] = ResultOf[T](ret)
+	}
+	return results
+}
+
+func successFunctionOf[T any](successFunction TypedSuccessFunction[T]) SuccessFunction {
+	return func(returns []Return) {
+		successFunction(resultsOf[T](returns))
+	}
+}
+
+func failFunctionOf[T any](failFunction TypedFailFunction[T]) FailFunction {
+	return func(returns []Return, err error) {
+		failFunction(resultsOf[T](returns), err)
+	}
+}
+
+// JoinFailOnAnyErrorTyped typed version of JoinFailOnAnyError
+func JoinFailOnAnyErrorTyped[T any](funcs ...TypedFunction[T]) ([]Result[T], error) {
+	returns, err := JoinFailOnAnyError(functionsOf(funcs)...)
+	return resultsOf[T](returns), err
+}
+
+// JoinFailOnAnyErrorSuccessFailFunctionTyped typed version of JoinFailOnAnyErrorSuccessFailFunction
+func JoinFailOnAnyErrorSuccessFailFunctionTyped[T any](successFunction TypedSuccessFunction[T], failFunction TypedFailFunction[T], funcs ...TypedFunction[T]) {
+	JoinFailOnAnyErrorSuccessFailFunction(successFunctionOf(successFunction), failFunctionOf(failFunction), functionsOf(funcs)...)
+}
+
+// JoinCompleteAllTyped typed version of JoinCompleteAll
+func JoinCompleteAllTyped[T any](funcs ...TypedFunction[T]) ([]Result[T], bool) {
+	returns, isSuccess := JoinCompleteAll(functionsOf(funcs)...)
+	return resultsOf[T](returns), isSuccess
+}
+
+// JoinCompleteAllSuccessFailFunctionTyped typed version of JoinCompleteAllSuccessFailFunction
+func JoinCompleteAllSuccessFailFunctionTyped[T any](successFunction TypedSuccessFunction[T], failFunction TypedFailFunction[T], funcs ...TypedFunction[T]) {
+	JoinCompleteAllSuccessFailFunction(successFunctionOf(successFunction), failFunctionOf(failFunction), functionsOf(funcs)...)
+}
+
+// JoinCompleteOnAnySuccessTyped typed version of JoinCompleteOnAnySuccess
+func JoinCompleteOnAnySuccessTyped[T any](funcs ...TypedFunction[T]) ([]Result[T], bool) {
+	returns, isSuccess := JoinCompleteOnAnySuccess(functionsOf(funcs)...)
+	return resultsOf[T](returns), isSuccess
+}
+
+// JoinCompleteOnAnySuccessSuccessFailFunctionTyped typed version of JoinCompleteOnAnySuccessSuccessFailFunction
+func JoinCompleteOnAnySuccessSuccessFailFunctionTyped[T any](successFunction TypedSuccessFunction[T], failFunction TypedFailFunction[T], funcs ...TypedFunction[T]) {
+	JoinCompleteOnAnySuccessSuccessFailFunction(successFunctionOf(successFunction), failFunctionOf(failFunction), functionsOf(funcs)...)
+}
+
+// JoinFailOnErrorOrTimeoutTyped typed version of JoinFailOnErrorOrTimeout
+func JoinFailOnErrorOrTimeoutTyped[T any](duration time.Duration, funcs ...TypedFunction[T]) ([]Result[T], error) {
+	returns, err := JoinFailOnErrorOrTimeout(duration, functionsOf(funcs)...)
+	return resultsOf[T](returns), err
+}
+
+// JoinFailOnErrorOrTimeoutSuccessFailFunctionTyped typed version of JoinFailOnErrorOrTimeoutSuccessFailFunction
+func JoinFailOnErrorOrTimeoutSuccessFailFunctionTyped[T any](successFunction TypedSuccessFunction[T], failFunction TypedFailFunction[T], duration time.Duration, funcs ...TypedFunction[T]) {
+	JoinFailOnErrorOrTimeoutSuccessFailFunction(successFunctionOf(successFunction), failFunctionOf(failFunction), duration, functionsOf(funcs)...)
+}
+
+// JoinFailOnAnyErrorContextTyped typed version of JoinFailOnAnyErrorContext
+func JoinFailOnAnyErrorContextTyped[T any](ctx context.Context, funcs ...TypedContextFunction[T]) ([]Result[T], error) {
+	returns, err := JoinFailOnAnyErrorContext(ctx, contextFunctionsOf(funcs)...)
+	return resultsOf[T](returns), err
+}
+
+// JoinFailOnErrorOrTimeoutContextTyped typed version of JoinFailOnErrorOrTimeoutContext
+func JoinFailOnErrorOrTimeoutContextTyped[T any](ctx context.Context, duration time.Duration, funcs ...TypedContextFunction[T]) ([]Result[T], error) {
+	returns, err := JoinFailOnErrorOrTimeoutContext(ctx, duration, contextFunctionsOf(funcs)...)
+	return resultsOf[T](returns), err
+}
+
+// JoinCompleteOnAnySuccessContextTyped typed version of JoinCompleteOnAnySuccessContext
+func JoinCompleteOnAnySuccessContextTyped[T any](ctx context.Context, funcs ...TypedContextFunction[T]) ([]Result[T], bool) {
+	returns, isSuccess := JoinCompleteOnAnySuccessContext(ctx, contextFunctionsOf(funcs)...)
+	return resultsOf[T](returns), isSuccess
+}
+
+// Tuple2 values returned by Join2
+type Tuple2[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 values returned by Join3
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Join2 Run two functions of different types and return when complete or when any function fail
+func Join2[A, B any](first TypedFunction[A], second TypedFunction[B]) (Tuple2[A, B], error) {
+	returns, err := JoinFailOnAnyError(first.Function(), second.Function())
+	if err != nil {
+		return Tuple2[A, B]{}, err
+	}
+	return Tuple2[A, B]{
+		First:  ResultOf[A](returns[0]).Value(),
+		Second: ResultOf[B](returns[1]).Value(),
+	}, nil
+}
+
+// Join3 Run three functions of different types and return when complete or when any function fail
+func Join3[A, B, C any](first TypedFunction[A], second TypedFunction[B], third TypedFunction[C]) (Tuple3[A, B, C], error) {
+	returns, err := JoinFailOnAnyError(first.Function(), second.Function(), third.Function())
+	if err != nil {
+		return Tuple3[A, B, C]{}, err
+	}
+	return Tuple3[A, B, C]{
+		First:  ResultOf[A](returns[0]).Value(),
+		Second: ResultOf[B](returns[1]).Value(),
+		Third:  ResultOf[C](returns[2]).Value(),
+	}, nil
+}
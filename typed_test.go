@@ -0,0 +1,155 @@
+package gauss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func typedSuccessFunction() (int, error) {
+	return 1, nil
+}
+
+func typedErrorFunction() (int, error) {
+	return 0, errNormal
+}
+
+func typedStringFunction() (string, error) {
+	return successValue, nil
+}
+
+func typedSuccessContextFunction(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+// Result tests
+
+func Test_GivenValueAndError_WhenNewResult_ThenReturnValueAndError(t *testing.T) {
+	result := NewResult(1, errNormal)
+	assert.Equal(t, 1, result.Value())
+	assert.ErrorIs(t, result.Err(), errNormal)
+}
+
+func Test_GivenUntypedReturn_WhenResultOf_ThenReturnFirstValue(t *testing.T) {
+	result := ResultOf[string](NewReturn(nil, successValue))
+	assert.Equal(t, successValue, result.Value())
+	assert.Nil(t, result.Err())
+}
+
+func Test_GivenReturnWithOtherType_WhenResultOf_ThenReturnZeroValue(t *testing.T) {
+	result := ResultOf[int](NewReturn(errNormal, successValue))
+	assert.Equal(t, 0, result.Value())
+	assert.ErrorIs(t, result.Err(), errNormal)
+}
+
+func Test_GivenNilReturn_WhenResultOf_ThenReturnNil(t *testing.T) {
+	assert.Nil(t, ResultOf[int](nil))
+}
+
+func Test_GivenTypedFunction_WhenJoinWithUntypedFunction_ThenReturnValues(t *testing.T) {
+	returns, err := JoinFailOnAnyError(TypedFunction[int](typedSuccessFunction).Function(), successFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, returns[0].ReturnValues()[0])
+	assert.Equal(t, successValue, returns[1].ReturnValues()[0])
+}
+
+// Typed joins tests
+
+func Test_GivenSuccessFunctions_WhenJoinFailOnAnyErrorTyped_ThenReturnTypedValues(t *testing.T) {
+	results, err := JoinFailOnAnyErrorTyped(typedSuccessFunction, typedSuccessFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, results[0].Value()+results[1].Value())
+}
+
+func Test_GivenFailFunction_WhenJoinFailOnAnyErrorSuccessFailFunctionTyped_ThenCallFailFunction(t *testing.T) {
+	JoinFailOnAnyErrorSuccessFailFunctionTyped(func(results []Result[int]) {
+		assert.True(t, false, "JoinFailOnAnyErrorSuccessFailFunctionTyped must no call success function")
+	}, func(results []Result[int], err error) {
+		assert.ErrorIs(t, err, errNormal)
+	}, typedErrorFunction)
+}
+
+func Test_GivenFailFunction_WhenJoinCompleteAllTyped_ThenReturnFalse(t *testing.T) {
+	results, isSuccess := JoinCompleteAllTyped(typedSuccessFunction, typedErrorFunction)
+	assert.False(t, isSuccess)
+	assert.Equal(t, 1, results[0].Value())
+	assert.ErrorIs(t, results[1].Err(), errNormal)
+}
+
+func Test_GivenSuccessFunctions_WhenJoinCompleteAllSuccessFailFunctionTyped_ThenCallSuccessFunction(t *testing.T) {
+	JoinCompleteAllSuccessFailFunctionTyped(func(results []Result[int]) {
+		assert.Equal(t, 1, results[0].Value())
+	}, func(results []Result[int], err error) {
+		assert.True(t, false, "JoinCompleteAllSuccessFailFunctionTyped must no call fail function")
+	}, typedSuccessFunction)
+}
+
+func Test_GivenOneSuccessFunction_WhenJoinCompleteOnAnySuccessTyped_ThenReturnTrue(t *testing.T) {
+	_, isSuccess := JoinCompleteOnAnySuccessTyped(typedErrorFunction, typedSuccessFunction)
+	assert.True(t, isSuccess)
+}
+
+func Test_GivenFailFunctions_WhenJoinCompleteOnAnySuccessSuccessFailFunctionTyped_ThenCallFailFunction(t *testing.T) {
+	JoinCompleteOnAnySuccessSuccessFailFunctionTyped(func(results []Result[int]) {
+		assert.True(t, false, "JoinCompleteOnAnySuccessSuccessFailFunctionTyped must no call success function")
+	}, func(results []Result[int], err error) {
+		assert.ErrorIs(t, err, errNormal)
+	}, typedErrorFunction, typedErrorFunction)
+}
+
+func Test_GivenSuccessFunction_WhenJoinFailOnErrorOrTimeoutTyped_ThenReturnNilError(t *testing.T) {
+	results, err := JoinFailOnErrorOrTimeoutTyped(time.Second, typedSuccessFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, results[0].Value())
+}
+
+func Test_GivenSuccessFunction_WhenJoinFailOnErrorOrTimeoutSuccessFailFunctionTyped_ThenCallSuccessFunction(t *testing.T) {
+	JoinFailOnErrorOrTimeoutSuccessFailFunctionTyped(func(results []Result[int]) {
+		assert.Equal(t, 1, results[0].Value())
+	}, func(results []Result[int], err error) {
+		assert.True(t, false, "JoinFailOnErrorOrTimeoutSuccessFailFunctionTyped must no call fail function")
+	}, time.Second, typedSuccessFunction)
+}
+
+func Test_GivenSuccessFunction_WhenJoinFailOnAnyErrorContextTyped_ThenReturnNilError(t *testing.T) {
+	results, err := JoinFailOnAnyErrorContextTyped(context.Background(), typedSuccessContextFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, results[0].Value())
+}
+
+func Test_GivenSuccessFunction_WhenJoinFailOnErrorOrTimeoutContextTyped_ThenReturnNilError(t *testing.T) {
+	_, err := JoinFailOnErrorOrTimeoutContextTyped(context.Background(), time.Second, typedSuccessContextFunction)
+	assert.Nil(t, err)
+}
+
+func Test_GivenSuccessFunction_WhenJoinCompleteOnAnySuccessContextTyped_ThenReturnTrue(t *testing.T) {
+	_, isSuccess := JoinCompleteOnAnySuccessContextTyped(context.Background(), typedSuccessContextFunction)
+	assert.True(t, isSuccess)
+}
+
+// Join2 and Join3 tests
+
+func Test_GivenSuccessFunctions_WhenJoin2_ThenReturnTuple(t *testing.T) {
+	tuple, err := Join2(typedSuccessFunction, typedStringFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, tuple.First)
+	assert.Equal(t, successValue, tuple.Second)
+}
+
+func Test_GivenFailFunction_WhenJoin2_ThenReturnError(t *testing.T) {
+	_, err := Join2(typedErrorFunction, typedStringFunction)
+	assert.ErrorIs(t, err, errNormal)
+}
+
+func Test_GivenSuccessFunctions_WhenJoin3_ThenReturnTuple(t *testing.T) {
+	tuple, err := Join3(typedSuccessFunction, typedStringFunction, typedSuccessFunction)
+	assert.Nil(t, err)
+	assert.Equal(t, Tuple3[int, string, int]{First: 1, Second: successValue, Third: 1}, tuple)
+}
+
+func Test_GivenFailFunction_WhenJoin3_ThenReturnError(t *testing.T) {
+	_, err := Join3(typedSuccessFunction, typedStringFunction, typedErrorFunction)
+	assert.ErrorIs(t, err, errNormal)
+}